@@ -259,6 +259,9 @@ func (c *CelerdataClient) attempt(
 			err = fmt.Errorf("response body: %w", responseBodyErr)
 		}
 
+		if err == nil && response.StatusCode >= http.StatusBadRequest && !hasBusinessCode(responseBody.Bytes()) {
+			err = &HTTPError{StatusCode: response.StatusCode, Method: method, Path: request.URL.Path, Body: responseBody.String()}
+		}
 		if err == nil {
 			return &responseBody, nil
 		}
@@ -273,6 +276,44 @@ func (c *CelerdataClient) attempt(
 	}
 }
 
+// HTTPError is returned when CelerData responds with an HTTP error status and no business code, for example when
+// the deployment doesn't serve the endpoint.
+type HTTPError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s %s failed with status %d: %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// IsUnsupported reports whether err means that the CelerData deployment doesn't serve the endpoint.
+func IsUnsupported(err error) bool {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	switch httpErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// hasBusinessCode reports whether body is a response of CelerData that carries a business code, which Do turns
+// into an error with its message.
+func hasBusinessCode(body []byte) bool {
+	resp := &struct {
+		Code *int `json:"code"`
+	}{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return false
+	}
+	return resp.Code != nil && *resp.Code != 0
+}
+
 func canRetry(r *http.Response) bool {
 	if r == nil {
 		return false
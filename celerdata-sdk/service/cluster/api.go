@@ -72,6 +72,8 @@ type IClusterAPI interface {
 	ChangeWarehouseDistribution(ctx context.Context, req *ChangeWarehouseDistributionReq) (*ChangeWarehouseDistributionResp, error)
 
 	GetVmInfo(ctx context.Context, req *GetVmInfoReq) (*GetVmInfoResp, error)
+	ListVmInfo(ctx context.Context, req *ListVmInfoReq) (*ListVmInfoResp, error)
+	ListClusterVersions(ctx context.Context, req *ListClusterVersionsReq) (*ListClusterVersionsResp, error)
 	UpdateDeploymentScripts(ctx context.Context, req *UpdateDeploymentScriptsReq) error
 
 	ListClusterSchedulePolicy(ctx context.Context, req *ListClusterSchedulePolicyReq) (*ListClusterSchedulePolicyResp, error)
@@ -79,6 +81,10 @@ type IClusterAPI interface {
 	SaveClusterSchedulePolicy(ctx context.Context, req *SaveClusterSchedulePolicyReq) (*SaveClusterSchedulePolicyResp, error)
 	ModifyClusterSchedulePolicy(ctx context.Context, req *ModifyClusterSchedulePolicyReq) error
 	DeleteClusterSchedulePolicy(ctx context.Context, req *DeleteClusterSchedulePolicyReq) error
+	ListWarehouseSchedulePolicy(ctx context.Context, req *ListWarehouseSchedulePolicyReq) (*ListWarehouseSchedulePolicyResp, error)
+	SaveWarehouseSchedulePolicy(ctx context.Context, req *SaveWarehouseSchedulePolicyReq) (*SaveWarehouseSchedulePolicyResp, error)
+	ModifyWarehouseSchedulePolicy(ctx context.Context, req *ModifyWarehouseSchedulePolicyReq) error
+	DeleteWarehouseSchedulePolicy(ctx context.Context, req *DeleteWarehouseSchedulePolicyReq) error
 	GetGlobalSqlSessionVariables(ctx context.Context, req *GetGlobalSqlSessionVariablesReq) (*GetGlobalSqlSessionVariablesResp, error)
 	SetGlobalSqlSessionVariables(ctx context.Context, req *SetGlobalSqlSessionVariablesReq) (*SetGlobalSqlSessionVariablesResp, error)
 	ResetGlobalSqlSessionVariables(ctx context.Context, req *ResetGlobalSqlSessionVariablesReq) (*ResetGlobalSqlSessionVariablesResp, error)
@@ -110,6 +116,21 @@ func (c *clusterAPI) RunScripts(ctx context.Context, req *RunScriptsReq) error {
 	return c.cli.Patch(ctx, fmt.Sprintf("/api/%s/clusters/%s/run-scripts", c.apiVersion, req.ClusterId), req, nil)
 }
 
+// ListClusterVersions implements IClusterAPI.
+func (c *clusterAPI) ListClusterVersions(ctx context.Context, req *ListClusterVersionsReq) (*ListClusterVersionsResp, error) {
+	resp := &ListClusterVersionsResp{}
+	err := c.cli.Get(ctx, fmt.Sprintf("/api/%s/cluster-versions", c.apiVersion), map[string]string{
+		"csp":        req.Csp,
+		"region":     req.Region,
+		"arch":       req.Arch,
+		"cluster_id": req.ClusterId,
+	}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetVmInfo implements IClusterAPI.
 func (c *clusterAPI) GetVmInfo(ctx context.Context, req *GetVmInfoReq) (*GetVmInfoResp, error) {
 	resp := &GetVmInfoResp{}
@@ -125,6 +146,21 @@ func (c *clusterAPI) GetVmInfo(ctx context.Context, req *GetVmInfoReq) (*GetVmIn
 	return resp, nil
 }
 
+// ListVmInfo implements IClusterAPI. The endpoint is not served by every CelerData deployment yet,
+// VmCatalog falls back to GetVmInfo when it fails.
+func (c *clusterAPI) ListVmInfo(ctx context.Context, req *ListVmInfoReq) (*ListVmInfoResp, error) {
+	resp := &ListVmInfoResp{}
+	err := c.cli.Get(ctx, fmt.Sprintf("/api/%s/vm-instance/infos", c.apiVersion), map[string]string{
+		"csp":          req.Csp,
+		"region":       req.Region,
+		"process_type": req.ProcessType,
+	}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *clusterAPI) UpdateDeploymentScripts(ctx context.Context, req *UpdateDeploymentScriptsReq) error {
 	return c.cli.Post(ctx, fmt.Sprintf("/api/%s/clusters/%s/deployment-scripts", c.apiVersion, req.ClusterId), req, nil)
 }
@@ -639,6 +675,39 @@ func (c *clusterAPI) DeleteClusterSchedulePolicy(ctx context.Context, req *Delet
 	return nil
 }
 
+// The scheduling policy endpoints of warehouses mirror the ones of clusters. They are not documented yet and not
+// served by every CelerData deployment, see client.IsUnsupported.
+func (c *clusterAPI) ListWarehouseSchedulePolicy(ctx context.Context, req *ListWarehouseSchedulePolicyReq) (*ListWarehouseSchedulePolicyResp, error) {
+	var schedulePolicies []*WarehouseSchedulePolicy
+	err := c.cli.Get(ctx, fmt.Sprintf("/api/%s/warehouses/%s/scheduling-policies", c.apiVersion, req.WarehouseId), nil, &schedulePolicies)
+	if err != nil {
+		return nil, err
+	}
+	return &ListWarehouseSchedulePolicyResp{
+		SchedulePolicies: schedulePolicies,
+	}, nil
+}
+
+func (c *clusterAPI) SaveWarehouseSchedulePolicy(ctx context.Context, req *SaveWarehouseSchedulePolicyReq) (*SaveWarehouseSchedulePolicyResp, error) {
+	resp := &SaveWarehouseSchedulePolicyResp{}
+	err := c.cli.Post(ctx, fmt.Sprintf("/api/%s/warehouses/%s/scheduling-policies", c.apiVersion, req.WarehouseId), req, resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.PolicyId) == 0 {
+		return nil, fmt.Errorf("save scheduling policy[%s] of warehouse[%s]: no policy id in the response", req.PolicyName, req.WarehouseId)
+	}
+	return resp, nil
+}
+
+func (c *clusterAPI) ModifyWarehouseSchedulePolicy(ctx context.Context, req *ModifyWarehouseSchedulePolicyReq) error {
+	return c.cli.Put(ctx, fmt.Sprintf("/api/%s/warehouses/%s/scheduling-policies/%s", c.apiVersion, req.WarehouseId, req.PolicyId), req, nil)
+}
+
+func (c *clusterAPI) DeleteWarehouseSchedulePolicy(ctx context.Context, req *DeleteWarehouseSchedulePolicyReq) error {
+	return c.cli.Delete(ctx, fmt.Sprintf("/api/%s/warehouses/%s/scheduling-policies/%s", c.apiVersion, req.WarehouseId, req.PolicyId), nil, nil)
+}
+
 func (c *clusterAPI) GetGlobalSqlSessionVariables(ctx context.Context, req *GetGlobalSqlSessionVariablesReq) (*GetGlobalSqlSessionVariablesResp, error) {
 	variables := make(map[string]string)
 	err := c.cli.Get(ctx, fmt.Sprintf("/api/%s/clusters/%s/global/sql-session/variables", c.apiVersion, req.ClusterId), map[string]string{
@@ -600,6 +600,7 @@ type ClusterInfo struct {
 	ClusterName    string `json:"cluster_name" mapstructure:"cluster_name"`
 	ClusterVersion string `json:"cluster_version" mapstructure:"cluster_version"`
 	ClusterType    string `json:"cluster_type" mapstructure:"cluster_type"`
+	CreatedAt      int64  `json:"created_at" mapstructure:"created_at"`
 }
 
 type ListClusterResp struct {
@@ -797,6 +798,8 @@ type VMInfo struct {
 	ProcessType      string          `json:"process_type" mapstructure:"process_type"` // FE/BE
 	VmCate           string          `json:"vm_cate" mapstructure:"vm_cate"`
 	Arch             string          `json:"arch" mapstructure:"arch"`
+	CpuCores         int32           `json:"cpu_cores" mapstructure:"cpu_cores"`
+	MemoryGb         int64           `json:"memory_gb" mapstructure:"memory_gb"`
 	IsInstanceStore  bool            `json:"is_instance_store" mapstructure:"is_instance_store"`
 	MaxDataDiskCount uint32          `json:"max_data_disk_count" mapstructure:"max_data_disk_count"`
 	VmVolumeInfos    []*VmVolumeInfo `json:"vm_volume_infos" mapstructure:"vm_volume_infos"`
@@ -806,6 +809,48 @@ type GetVmInfoResp struct {
 	VmInfo *VMInfo `json:"vm_info" mapstructure:"vm_info"`
 }
 
+type ListVmInfoReq struct {
+	Csp         string `json:"csp" mapstructure:"csp"`
+	Region      string `json:"region" mapstructure:"region"`
+	ProcessType string `json:"process_type" mapstructure:"process_type"`
+}
+
+type ListVmInfoResp struct {
+	VmInfos []*VMInfo `json:"vm_infos" mapstructure:"vm_infos"`
+}
+
+type ListClusterVersionsReq struct {
+	Csp    string `json:"csp" mapstructure:"csp"`
+	Region string `json:"region" mapstructure:"region"`
+	Arch   string `json:"arch" mapstructure:"arch"`
+	// Optional, the upgradable flags of the versions are only set when it's specified.
+	ClusterId string `json:"cluster_id" mapstructure:"cluster_id"`
+}
+
+type ClusterVersionInfo struct {
+	Version     string `json:"version" mapstructure:"version"`
+	ReleaseDate string `json:"release_date" mapstructure:"release_date"`
+	EolDate     string `json:"eol_date" mapstructure:"eol_date"`
+	IsEol       bool   `json:"is_eol" mapstructure:"is_eol"`
+	IsDefault   bool   `json:"is_default" mapstructure:"is_default"`
+	Upgradable  bool   `json:"upgradable" mapstructure:"upgradable"`
+}
+
+type BaseImageInfo struct {
+	OS          string `json:"os" mapstructure:"os"`
+	Arch        string `json:"arch" mapstructure:"arch"`
+	Description string `json:"description" mapstructure:"description"`
+	ReleaseDate string `json:"release_date" mapstructure:"release_date"`
+	EolDate     string `json:"eol_date" mapstructure:"eol_date"`
+	IsEol       bool   `json:"is_eol" mapstructure:"is_eol"`
+	IsDefault   bool   `json:"is_default" mapstructure:"is_default"`
+}
+
+type ListClusterVersionsResp struct {
+	Versions   []*ClusterVersionInfo `json:"versions" mapstructure:"versions"`
+	BaseImages []*BaseImageInfo      `json:"base_images" mapstructure:"base_images"`
+}
+
 type UpdateResourceTagsReq struct {
 	ClusterId   string            `json:"cluster_id"`
 	WarehouseId string            `json:"warehouse_id"`
@@ -983,6 +1028,58 @@ type SaveClusterSchedulePolicyResp struct {
 	PolicyId string `json:"policy_id" mapstructure:"policy_id"`
 }
 
+type ListWarehouseSchedulePolicyReq struct {
+	WarehouseId string `json:"warehouse_id" mapstructure:"warehouse_id"`
+}
+
+type ListWarehouseSchedulePolicyResp struct {
+	SchedulePolicies []*WarehouseSchedulePolicy `json:"schedule_policies" mapstructure:"schedule_policies"`
+}
+
+type WarehouseSchedulePolicy struct {
+	PolicyId        string `json:"policy_id" mapstructure:"policy_id"`
+	WarehouseId     string `json:"warehouse_id" mapstructure:"warehouse_id"`
+	PolicyName      string `json:"policy_name" mapstructure:"policy_name"`
+	Description     string `json:"description" mapstructure:"description"`
+	TimeZone        string `json:"time_zone" mapstructure:"time_zone"`
+	ActiveDateValue string `json:"active_date_value" mapstructure:"active_date_value"`
+	ResumeAt        string `json:"resume_at" mapstructure:"resume_at"`
+	SuspendAt       string `json:"suspend_at" mapstructure:"suspend_at"`
+	State           int32  `json:"state" mapstructure:"state"`
+}
+
+type SaveWarehouseSchedulePolicyReq struct {
+	WarehouseId string `json:"warehouse_id" mapstructure:"warehouse_id"`
+	PolicyName  string `json:"policy_name" mapstructure:"policy_name"`
+	Description string `json:"description" mapstructure:"description"`
+	TimeZone    string `json:"time_zone" mapstructure:"time_zone"`
+	ActiveDays  string `json:"active_days" mapstructure:"active_days"`
+	ResumeAt    string `json:"resume_at" mapstructure:"resume_at"`
+	SuspendAt   string `json:"suspend_at" mapstructure:"suspend_at"`
+	State       bool   `json:"state" mapstructure:"state"`
+}
+
+type SaveWarehouseSchedulePolicyResp struct {
+	PolicyId string `json:"policy_id" mapstructure:"policy_id"`
+}
+
+type ModifyWarehouseSchedulePolicyReq struct {
+	PolicyId    string `json:"policy_id" mapstructure:"policy_id"`
+	WarehouseId string `json:"warehouse_id" mapstructure:"warehouse_id"`
+	PolicyName  string `json:"policy_name" mapstructure:"policy_name"`
+	Description string `json:"description" mapstructure:"description"`
+	TimeZone    string `json:"time_zone" mapstructure:"time_zone"`
+	ActiveDays  string `json:"active_days" mapstructure:"active_days"`
+	ResumeAt    string `json:"resume_at" mapstructure:"resume_at"`
+	SuspendAt   string `json:"suspend_at" mapstructure:"suspend_at"`
+	State       bool   `json:"state" mapstructure:"state"`
+}
+
+type DeleteWarehouseSchedulePolicyReq struct {
+	WarehouseId string `json:"warehouse_id" mapstructure:"warehouse_id"`
+	PolicyId    string `json:"policy_id" mapstructure:"policy_id"`
+}
+
 type RunScriptsReq struct {
 	ClusterId          string    `json:"cluster_id" mapstructure:"cluster_id"`
 	Scripts            []*Script `json:"scripts" mapstructure:"scripts"`
@@ -993,16 +1090,24 @@ func Equal(a, b interface{}) bool {
 	return cmp.Equal(a, b)
 }
 
+// The default volumes of coordinator (FE) and compute (BE) nodes.
+const (
+	DefaultFeVolNumber = 1
+	DefaultFeVolSize   = 150
+	DefaultBeVolNumber = 2
+	DefaultBeVolSize   = 100
+)
+
 func DefaultFeVolumeMap() map[string]interface{} {
 	volumeConfig := make(map[string]interface{})
-	volumeConfig["vol_size"] = 150
+	volumeConfig["vol_size"] = DefaultFeVolSize
 	return volumeConfig
 }
 
 func DefaultBeVolumeMap() map[string]interface{} {
 	volumeConfig := make(map[string]interface{})
-	volumeConfig["vol_number"] = 2
-	volumeConfig["vol_size"] = 100
+	volumeConfig["vol_number"] = DefaultBeVolNumber
+	volumeConfig["vol_size"] = DefaultBeVolSize
 	return volumeConfig
 }
 
@@ -0,0 +1,169 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var vmCatalogs sync.Map
+
+// SharedVmCatalog returns the vm catalog bound to cli. Since the provider keeps a single client for its
+// whole lifetime, every resource of the provider shares the same catalog.
+func SharedVmCatalog(cli *client.CelerdataClient) *VmCatalog {
+	if v, ok := vmCatalogs.Load(cli); ok {
+		return v.(*VmCatalog)
+	}
+	v, _ := vmCatalogs.LoadOrStore(cli, NewVmCatalog(NewClustersAPI(cli)))
+	return v.(*VmCatalog)
+}
+
+// VmCatalog caches the vm instance types of each csp/region. The whole catalog of a region is fetched
+// with a single call the first time it's needed; types missing from it are looked up one by one and
+// cached as well. Successful volume parameter verifications are cached too. Failures are not cached, they may
+// be transient, except when the deployment doesn't serve the list endpoint: that's remembered per region and
+// only the types are looked up from then on. It's safe for concurrent use, calls of different regions don't
+// block each other.
+type VmCatalog struct {
+	api IClusterAPI
+
+	mu              sync.Mutex
+	regions         map[string]*regionVmCatalog
+	verifiedVolumes map[ModifyClusterVolumeReq]bool
+}
+
+// regionVmCatalog is the catalog of a csp/region, mu is held while the region is fetched from CelerData.
+type regionVmCatalog struct {
+	mu     sync.Mutex
+	listed bool
+	// listErr is the permanent failure of the list endpoint, which isn't called again.
+	listErr error
+	vmInfos map[string]*VMInfo
+}
+
+func NewVmCatalog(api IClusterAPI) *VmCatalog {
+	return &VmCatalog{
+		api:             api,
+		regions:         make(map[string]*regionVmCatalog),
+		verifiedVolumes: make(map[ModifyClusterVolumeReq]bool),
+	}
+}
+
+func vmInfoKey(processType, vmCate string) string {
+	return fmt.Sprintf("%s/%s", strings.ToUpper(processType), vmCate)
+}
+
+// lockRegion returns the locked catalog of csp/region.
+func (c *VmCatalog) lockRegion(csp, region string) *regionVmCatalog {
+	key := fmt.Sprintf("%s/%s", csp, region)
+
+	c.mu.Lock()
+	rc, ok := c.regions[key]
+	if !ok {
+		rc = &regionVmCatalog{vmInfos: make(map[string]*VMInfo)}
+		c.regions[key] = rc
+	}
+	c.mu.Unlock()
+
+	rc.mu.Lock()
+	return rc
+}
+
+// list fetches the whole catalog of csp/region unless it's fetched already, rc must be locked.
+func (c *VmCatalog) list(ctx context.Context, rc *regionVmCatalog, csp, region string) error {
+	if rc.listed {
+		return nil
+	}
+	if rc.listErr != nil {
+		return rc.listErr
+	}
+
+	// Not every CelerData deployment serves the list endpoint yet, callers fall back to GetVmInfo on failures.
+	resp, err := c.api.ListVmInfo(ctx, &ListVmInfoReq{Csp: csp, Region: region})
+	if err != nil {
+		log.Printf("[WARN] list vm info failed, csp:%s region:%s err:%+v", csp, region, err)
+		if client.IsUnsupported(err) || status.Code(err) == codes.NotFound {
+			rc.listErr = err
+		}
+		return err
+	}
+	for _, v := range resp.VmInfos {
+		rc.vmInfos[vmInfoKey(v.ProcessType, v.VmCate)] = v
+	}
+	rc.listed = true
+	log.Printf("[DEBUG] cached %d vm instance types, csp:%s region:%s", len(resp.VmInfos), csp, region)
+	return nil
+}
+
+// List returns all the cached vm instance types of csp/region.
+func (c *VmCatalog) List(ctx context.Context, csp, region string) ([]*VMInfo, error) {
+	rc := c.lockRegion(csp, region)
+	defer rc.mu.Unlock()
+
+	if err := c.list(ctx, rc, csp, region); err != nil {
+		return nil, fmt.Errorf("failed to list vm instance types, csp:%s region:%s errMsg:%s", csp, region, err.Error())
+	}
+
+	ret := make([]*VMInfo, 0, len(rc.vmInfos))
+	for _, v := range rc.vmInfos {
+		if v != nil {
+			ret = append(ret, v)
+		}
+	}
+	return ret, nil
+}
+
+// Get returns the vm info of vmCate, or nil if csp/region doesn't provide the vm instance type.
+func (c *VmCatalog) Get(ctx context.Context, csp, region, processType, vmCate string) (*VMInfo, error) {
+	rc := c.lockRegion(csp, region)
+	defer rc.mu.Unlock()
+
+	key := vmInfoKey(processType, vmCate)
+	if v, ok := rc.vmInfos[key]; ok {
+		return v, nil
+	}
+	if err := c.list(ctx, rc, csp, region); err == nil {
+		if v, ok := rc.vmInfos[key]; ok {
+			return v, nil
+		}
+	}
+
+	resp, err := c.api.GetVmInfo(ctx, &GetVmInfoReq{
+		Csp:         csp,
+		Region:      region,
+		ProcessType: processType,
+		VmCate:      vmCate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rc.vmInfos[key] = resp.VmInfo
+	return resp.VmInfo, nil
+}
+
+// VerifyVolume verifies the volume parameters. Parameters that passed the verification once are not sent again.
+func (c *VmCatalog) VerifyVolume(ctx context.Context, req *ModifyClusterVolumeReq) error {
+	c.mu.Lock()
+	verified := c.verifiedVolumes[*req]
+	c.mu.Unlock()
+	if verified {
+		return nil
+	}
+
+	err := c.api.VolumeParamVerification(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.verifiedVolumes[*req] = true
+	c.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,247 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeVmInfoAPI struct {
+	IClusterAPI
+
+	mu         sync.Mutex
+	listErrs   []error
+	vmInfos    []*VMInfo
+	verifyErr  error
+	listCalls  int
+	getCalls   int
+	verifyReqs int
+}
+
+func (f *fakeVmInfoAPI) ListVmInfo(ctx context.Context, req *ListVmInfoReq) (*ListVmInfoResp, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.listCalls++
+	if len(f.listErrs) > 0 {
+		err := f.listErrs[0]
+		f.listErrs = f.listErrs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &ListVmInfoResp{VmInfos: f.vmInfos}, nil
+}
+
+func (f *fakeVmInfoAPI) GetVmInfo(ctx context.Context, req *GetVmInfoReq) (*GetVmInfoResp, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.getCalls++
+	for _, v := range f.vmInfos {
+		if vmInfoKey(v.ProcessType, v.VmCate) == vmInfoKey(req.ProcessType, req.VmCate) {
+			return &GetVmInfoResp{VmInfo: v}, nil
+		}
+	}
+	return &GetVmInfoResp{}, nil
+}
+
+func (f *fakeVmInfoAPI) VolumeParamVerification(ctx context.Context, req *ModifyClusterVolumeReq) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.verifyReqs++
+	return f.verifyErr
+}
+
+func TestVmCatalogGet(t *testing.T) {
+	m6i := &VMInfo{ProcessType: "FE", VmCate: "m6i.xlarge", Arch: "x86_64"}
+	m6g := &VMInfo{ProcessType: "BE", VmCate: "m6g.xlarge", Arch: "arm64"}
+	errList := errors.New("not found")
+	errUnsupported := &client.HTTPError{StatusCode: http.StatusNotFound, Method: http.MethodGet, Path: "/api/1.0/vm-instance/infos"}
+	errNotFound := status.Error(codes.NotFound, "not found")
+
+	cases := []struct {
+		name      string
+		listErrs  []error
+		gets      []string
+		want      []*VMInfo
+		listCalls int
+		getCalls  int
+	}{
+		{
+			name:      "listed once",
+			gets:      []string{"m6i.xlarge", "m6i.xlarge"},
+			want:      []*VMInfo{m6i, m6i},
+			listCalls: 1,
+		},
+		{
+			name:      "missing type looked up and cached",
+			gets:      []string{"r6i.xlarge", "r6i.xlarge"},
+			want:      []*VMInfo{nil, nil},
+			listCalls: 1,
+			getCalls:  1,
+		},
+		{
+			name:      "list failure falls back and is retried",
+			listErrs:  []error{errList},
+			gets:      []string{"m6i.xlarge", "m6g.xlarge"},
+			want:      []*VMInfo{m6i, m6g},
+			listCalls: 2,
+			getCalls:  1,
+		},
+		{
+			name:      "persistent list failure",
+			listErrs:  []error{errList, errList},
+			gets:      []string{"m6i.xlarge", "m6g.xlarge"},
+			want:      []*VMInfo{m6i, m6g},
+			listCalls: 2,
+			getCalls:  2,
+		},
+		{
+			name:      "unsupported list endpoint not retried",
+			listErrs:  []error{errUnsupported},
+			gets:      []string{"m6i.xlarge", "m6g.xlarge", "r6i.xlarge"},
+			want:      []*VMInfo{m6i, m6g, nil},
+			listCalls: 1,
+			getCalls:  3,
+		},
+		{
+			name:      "not found list not retried",
+			listErrs:  []error{errNotFound},
+			gets:      []string{"m6i.xlarge", "m6g.xlarge"},
+			want:      []*VMInfo{m6i, m6g},
+			listCalls: 1,
+			getCalls:  2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &fakeVmInfoAPI{listErrs: tc.listErrs, vmInfos: []*VMInfo{m6i, m6g}}
+			c := NewVmCatalog(api)
+			for i, vmCate := range tc.gets {
+				processType := "fe"
+				if vmCate == m6g.VmCate {
+					processType = "be"
+				}
+				got, err := c.Get(context.Background(), "aws", "us-west-2", processType, vmCate)
+				if err != nil {
+					t.Fatalf("Get(%s) returned error: %v", vmCate, err)
+				}
+				if got != tc.want[i] {
+					t.Errorf("Get(%s) = %+v, want %+v", vmCate, got, tc.want[i])
+				}
+			}
+			if api.listCalls != tc.listCalls {
+				t.Errorf("ListVmInfo called %d times, want %d", api.listCalls, tc.listCalls)
+			}
+			if api.getCalls != tc.getCalls {
+				t.Errorf("GetVmInfo called %d times, want %d", api.getCalls, tc.getCalls)
+			}
+		})
+	}
+}
+
+func TestVmCatalogList(t *testing.T) {
+	errList := errors.New("unavailable")
+
+	cases := []struct {
+		name     string
+		listErrs []error
+		wantErr  []bool
+	}{
+		{
+			name:    "success",
+			wantErr: []bool{false, false},
+		},
+		{
+			name:     "error not cached",
+			listErrs: []error{errList},
+			wantErr:  []bool{true, false},
+		},
+		{
+			name:     "unsupported error cached",
+			listErrs: []error{&client.HTTPError{StatusCode: http.StatusNotImplemented}},
+			wantErr:  []bool{true, true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &fakeVmInfoAPI{listErrs: tc.listErrs, vmInfos: []*VMInfo{{ProcessType: "BE", VmCate: "m6i.xlarge"}}}
+			c := NewVmCatalog(api)
+			for i, wantErr := range tc.wantErr {
+				got, err := c.List(context.Background(), "aws", "us-west-2")
+				if (err != nil) != wantErr {
+					t.Fatalf("List #%d error = %v, wantErr %v", i, err, wantErr)
+				}
+				if err == nil && len(got) != 1 {
+					t.Errorf("List #%d returned %d vm instance types, want 1", i, len(got))
+				}
+			}
+		})
+	}
+}
+
+func TestVmCatalogVerifyVolume(t *testing.T) {
+	cases := []struct {
+		name       string
+		verifyErr  error
+		verifyReqs int
+	}{
+		{
+			name:       "success cached",
+			verifyReqs: 1,
+		},
+		{
+			name:       "failure not cached",
+			verifyErr:  errors.New("invalid iops"),
+			verifyReqs: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &fakeVmInfoAPI{verifyErr: tc.verifyErr}
+			c := NewVmCatalog(api)
+			req := &ModifyClusterVolumeReq{ClusterId: "c1", Iops: 3000}
+			for i := 0; i < 2; i++ {
+				if err := c.VerifyVolume(context.Background(), req); (err != nil) != (tc.verifyErr != nil) {
+					t.Fatalf("VerifyVolume error = %v, want %v", err, tc.verifyErr)
+				}
+			}
+			if api.verifyReqs != tc.verifyReqs {
+				t.Errorf("VolumeParamVerification called %d times, want %d", api.verifyReqs, tc.verifyReqs)
+			}
+		})
+	}
+}
+
+func TestVmCatalogConcurrentRegions(t *testing.T) {
+	api := &fakeVmInfoAPI{vmInfos: []*VMInfo{{ProcessType: "FE", VmCate: "m6i.xlarge"}}}
+	c := NewVmCatalog(api)
+
+	var wg sync.WaitGroup
+	for _, region := range []string{"us-west-2", "us-east-1", "us-west-2", "us-east-1"} {
+		wg.Add(1)
+		go func(region string) {
+			defer wg.Done()
+			if _, err := c.Get(context.Background(), "aws", region, "FE", "m6i.xlarge"); err != nil {
+				t.Errorf("Get returned error: %v", err)
+			}
+		}(region)
+	}
+	wg.Wait()
+
+	if api.listCalls != 2 {
+		t.Errorf("ListVmInfo called %d times, want one call per region", api.listCalls)
+	}
+}
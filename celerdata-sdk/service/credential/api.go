@@ -13,15 +13,18 @@ type ICredentialAPI interface {
 	GetDeploymentRoleCredential(ctx context.Context, credID string) (*GetDeployRoleCredResp, error)
 	DeleteDeploymentRoleCredential(ctx context.Context, credID string) error
 	UpdateDeploymentRoleCredentialPolicyVersion(ctx context.Context, req *UpdateDeploymentRoleCredentialPolicyVersionReq) error
+	ListDeploymentRoleCredentials(ctx context.Context, req *ListCredentialsReq) (*ListDeployRoleCredsResp, error)
 	CreateDataCredential(ctx context.Context, req *CreateDataCredReq) (*CreateDataCredResp, error)
 	GetDataCredential(ctx context.Context, credID string) (*GetDataCredResp, error)
 	DeleteDataCredential(ctx context.Context, credID string) error
 	UpdateDataCredentialPolicyVersion(ctx context.Context, req *UpdateDataCredentialPolicyVersionReq) error
+	ListDataCredentials(ctx context.Context, req *ListCredentialsReq) (*ListDataCredsResp, error)
 
 	CreateDeploymentAkSkCredential(ctx context.Context, req *CreateDeployAkSkCredReq) (*CreateDeployAkSkCredResp, error)
 	GetDeploymentAkSkCredential(ctx context.Context, credID string) (*GetDeployAkSkCredResp, error)
 	RotateAkSkCredential(ctx context.Context, req *RotateAkSkCredentialReq) error
 	DeleteDeploymentAkSkCredential(ctx context.Context, credID string) error
+	ListDeploymentAkSkCredentials(ctx context.Context, req *ListCredentialsReq) (*ListDeployAkSkCredsResp, error)
 	CreateAzureDataCredential(ctx context.Context, req *CreateAzureDataCredReq) (*CreateDataCredResp, error)
 
 	CreateGcpDataCredential(ctx context.Context, req *CreateGcpDataCredReq) (*CreateDataCredResp, error)
@@ -77,6 +80,18 @@ func (c *credentialAPI) CreateDeploymentAkSkCredential(ctx context.Context, req
 	return resp, nil
 }
 
+// ListDeploymentAkSkCredentials filters the credentials by csp. The endpoint isn't verified against the API docs yet.
+func (c *credentialAPI) ListDeploymentAkSkCredentials(ctx context.Context, req *ListCredentialsReq) (*ListDeployAkSkCredsResp, error) {
+	resp := &ListDeployAkSkCredsResp{}
+
+	err := c.cli.Get(ctx, fmt.Sprintf("/api/%s/deploy-ak-sk-credentials", c.apiVersion), map[string]string{"csp": req.Csp}, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (c *credentialAPI) DeleteDeploymentAkSkCredential(ctx context.Context, credID string) error {
 	return c.cli.Delete(ctx, fmt.Sprintf("/api/%s/deploy-ak-sk-credentials/%s", c.apiVersion, credID), nil, nil)
 }
@@ -117,6 +132,18 @@ func (c *credentialAPI) GetDeploymentRoleCredential(ctx context.Context, credID
 	return resp, nil
 }
 
+// ListDeploymentRoleCredentials filters the credentials by csp. The endpoint isn't verified against the API docs yet.
+func (c *credentialAPI) ListDeploymentRoleCredentials(ctx context.Context, req *ListCredentialsReq) (*ListDeployRoleCredsResp, error) {
+	resp := &ListDeployRoleCredsResp{}
+
+	err := c.cli.Get(ctx, fmt.Sprintf("/api/%s/deploy-role-credentials", c.apiVersion), map[string]string{"csp": req.Csp}, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (c *credentialAPI) DeleteDeploymentRoleCredential(ctx context.Context, credID string) error {
 	return c.cli.Delete(ctx, fmt.Sprintf("/api/%s/deploy-role-credentials/%s", c.apiVersion, credID), nil, nil)
 }
@@ -145,6 +172,17 @@ func (c *credentialAPI) GetDataCredential(ctx context.Context, credID string) (*
 	return resp, nil
 }
 
+// ListDataCredentials filters the credentials by csp. The endpoint isn't verified against the API docs yet.
+func (c *credentialAPI) ListDataCredentials(ctx context.Context, req *ListCredentialsReq) (*ListDataCredsResp, error) {
+	resp := &ListDataCredsResp{}
+	err := c.cli.Get(ctx, fmt.Sprintf("/api/%s/data-credentials", c.apiVersion), map[string]string{"csp": req.Csp}, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (c *credentialAPI) DeleteDataCredential(ctx context.Context, credID string) error {
 	return c.cli.Delete(ctx, fmt.Sprintf("/api/%s/data-credentials/%s", c.apiVersion, credID), nil, nil)
 }
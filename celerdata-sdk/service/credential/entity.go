@@ -121,3 +121,19 @@ type CreateGcpDeployCredReq struct {
 	ServiceAccount string `json:"service_account" mapstructure:"service_account"`
 	ProjectId      string `json:"project_id" mapstructure:"project_id"`
 }
+
+type ListCredentialsReq struct {
+	Csp string `json:"csp" mapstructure:"csp"`
+}
+
+type ListDeployRoleCredsResp struct {
+	List []*DeploymentRoleCredential `json:"list" mapstructure:"list"`
+}
+
+type ListDataCredsResp struct {
+	List []*DataCredential `json:"list" mapstructure:"list"`
+}
+
+type ListDeployAkSkCredsResp struct {
+	List []*DeploymentAkSkCredential `json:"list" mapstructure:"list"`
+}
@@ -13,6 +13,7 @@ type INetworkAPI interface {
 	CreateAzureNetwork(ctx context.Context, req *CreateAzureNetworkReq) (*CreateNetworkResp, error)
 	CreateGcpNetwork(ctx context.Context, req *CreateGcpNetworkReq) (*CreateNetworkResp, error)
 	GetNetwork(ctx context.Context, netID string) (*GetNetworkResp, error)
+	ListNetworks(ctx context.Context, req *ListNetworksReq) (*ListNetworksResp, error)
 	DeleteNetwork(ctx context.Context, netID string) error
 }
 
@@ -64,6 +65,18 @@ func (c *networkAPI) GetNetwork(ctx context.Context, netID string) (*GetNetworkR
 	return resp, nil
 }
 
+// ListNetworks filters the networks by csp. The endpoint isn't verified against the API docs yet.
+func (c *networkAPI) ListNetworks(ctx context.Context, req *ListNetworksReq) (*ListNetworksResp, error) {
+	resp := &ListNetworksResp{}
+
+	err := c.cli.Get(ctx, fmt.Sprintf("/api/%s/networks", c.apiVersion), map[string]string{"csp": req.Csp}, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (c *networkAPI) DeleteNetwork(ctx context.Context, netID string) error {
 	return c.cli.Delete(ctx, fmt.Sprintf("/api/%s/networks/%s", c.apiVersion, netID), nil, nil)
 }
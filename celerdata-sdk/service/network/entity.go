@@ -48,6 +48,14 @@ type GetNetworkResp struct {
 	Network *Network `json:"network" mapstructure:"network"`
 }
 
+type ListNetworksReq struct {
+	Csp string `json:"csp" mapstructure:"csp"`
+}
+
+type ListNetworksResp struct {
+	List []*Network `json:"list" mapstructure:"list"`
+}
+
 type CreateGcpNetworkReq struct {
 	DeploymentCredentialID string   `json:"deployment_credential_id" mapstructure:"deployment_credential_id"`
 	Name                   string   `json:"name" mapstructure:"name"`
@@ -0,0 +1,282 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/csp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The data sources below replace the celerdatabyoc_aws_*_policy resources. Data sources are read on
+// every plan, so they pick up new versions of the policy templates.
+
+func dataAwsDataCredentialPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+			bucket := d.Get("bucket").(string)
+			policyJSON, version, err := renderAwsDataCredentialPolicy(ctx, m.(*client.CelerdataClient), bucket)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(fmt.Sprintf("data_credential:%s", bucket))
+			return setAwsPolicy(d, policyJSON, version)
+		},
+		Schema: awsPolicySchema(map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		}),
+	}
+}
+
+func dataAwsDeploymentCredentialPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+			dataRoleARN := d.Get("data_role_arn").(string)
+			bucket := d.Get("bucket").(string)
+			policyJSON, version, err := renderAwsDeploymentCredentialPolicy(ctx, m.(*client.CelerdataClient), dataRoleARN, bucket)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(fmt.Sprintf("deployment_credential:%s:%s", dataRoleARN, bucket))
+			return setAwsPolicy(d, policyJSON, version)
+		},
+		Schema: awsPolicySchema(map[string]*schema.Schema{
+			"data_role_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		}),
+	}
+}
+
+func dataAwsDeploymentCredentialAssumePolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+			externalID := d.Get("external_id").(string)
+			policyJSON, err := renderAwsDeploymentCredentialAssumePolicy(ctx, m.(*client.CelerdataClient), externalID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(fmt.Sprintf("deployment_credential_assume_role_policy:%s", externalID))
+			return setAwsPolicy(d, policyJSON, "")
+		},
+		Schema: awsPolicySchema(map[string]*schema.Schema{
+			"external_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The external ID that CelerData must provide to assume the role, such as `random_uuid.external_id.result`.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		}),
+	}
+}
+
+func awsPolicySchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["json"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["version"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["statements"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"sid": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"effect": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"actions": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"resources": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"principal": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The principal of the statement in JSON.",
+				},
+				"condition": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The condition of the statement in JSON.",
+				},
+			},
+		},
+	}
+	return s
+}
+
+func setAwsPolicy(d *schema.ResourceData, policyJSON, version string) diag.Diagnostics {
+	statements, err := flattenAwsPolicyStatements(policyJSON)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("json", policyJSON)
+	d.Set("version", version)
+	d.Set("statements", statements)
+	return nil
+}
+
+// flattenAwsPolicyStatements parses the policy loosely, the templates from the CSP API may use
+// either a string or a list for actions and resources.
+func flattenAwsPolicyStatements(policyJSON string) ([]interface{}, error) {
+	policy := struct {
+		Statements []map[string]interface{} `json:"Statement"`
+	}{}
+	if err := json.Unmarshal([]byte(policyJSON), &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse the policy: %s", err.Error())
+	}
+
+	toJSON := func(v interface{}) string {
+		if v == nil {
+			return ""
+		}
+		bytes, _ := json.Marshal(v)
+		return string(bytes)
+	}
+	toList := func(v interface{}) []string {
+		switch t := v.(type) {
+		case string:
+			return []string{t}
+		case []interface{}:
+			ret := make([]string, 0, len(t))
+			for _, item := range t {
+				ret = append(ret, fmt.Sprint(item))
+			}
+			return ret
+		}
+		return []string{}
+	}
+
+	ret := make([]interface{}, 0, len(policy.Statements))
+	for _, s := range policy.Statements {
+		sid, _ := s["Sid"].(string)
+		effect, _ := s["Effect"].(string)
+		ret = append(ret, map[string]interface{}{
+			"sid":       sid,
+			"effect":    effect,
+			"actions":   toList(s["Action"]),
+			"resources": toList(s["Resource"]),
+			"principal": toJSON(s["Principal"]),
+			"condition": toJSON(s["Condition"]),
+		})
+	}
+	return ret, nil
+}
+
+func getAwsCsp(ctx context.Context, c *client.CelerdataClient) (*csp.CSP, error) {
+	cspAPI := csp.NewCspAPI(c)
+	resp, err := cspAPI.Get(ctx, &csp.GetReq{
+		CspName: "aws",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Csp, nil
+}
+
+func renderAwsDataCredentialPolicy(ctx context.Context, c *client.CelerdataClient, bucket string) (string, string, error) {
+	awsCsp, err := getAwsCsp(ctx, c)
+	if err != nil {
+		return "", "", err
+	}
+	policyJSON := strings.Replace(awsCsp.DataCredPolicy, "<s3-bucket-name>", bucket, -1)
+	return policyJSON, awsCsp.DataCredPolicyVersion, nil
+}
+
+func renderAwsDeploymentCredentialPolicy(ctx context.Context, c *client.CelerdataClient, dataRoleARN, bucket string) (string, string, error) {
+	awsCsp, err := getAwsCsp(ctx, c)
+	if err != nil {
+		return "", "", err
+	}
+	policy := strings.Replace(awsCsp.DeployCredPolicy, "<Storage Role ARN>", dataRoleARN, -1)
+	policyJSON := strings.Replace(policy, "<s3-bucket-name>", bucket, -1)
+	return policyJSON, awsCsp.DeployCredPolicyVersion, nil
+}
+
+func renderAwsDeploymentCredentialAssumePolicy(ctx context.Context, c *client.CelerdataClient, externalID string) (string, error) {
+	awsCsp, err := getAwsCsp(ctx, c)
+	if err != nil {
+		return "", err
+	}
+
+	policy := awsIamPolicy{
+		Version: "2012-10-17",
+		Statements: []*awsIamPolicyStatement{
+			{
+				Effect:  "Allow",
+				Actions: "sts:AssumeRole",
+				Condition: map[string]map[string]string{
+					"StringEquals": {
+						"sts:ExternalId": externalID,
+					},
+				},
+				Principal: map[string]string{
+					"AWS": fmt.Sprintf("arn:aws:iam::%s:root", awsCsp.TrustAccountId),
+				},
+			},
+		},
+	}
+	policyJSON, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(policyJSON), nil
+}
+
+// checkAwsPolicyVersion warns when a credential was created with a policy version other than the
+// current one of the template, which means its IAM policy should be updated.
+func checkAwsPolicyVersion(ctx context.Context, c *client.CelerdataClient, kind, credID, policyVersion string, latest func(*csp.CSP) string) diag.Diagnostics {
+	awsCsp, err := getAwsCsp(ctx, c)
+	if err != nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to check the policy version of %s credential (%s)", kind, credID),
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	latestVersion := latest(awsCsp)
+	if len(latestVersion) == 0 || policyVersion == latestVersion {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The policy of %s credential (%s) is outdated", kind, credID),
+			Detail: fmt.Sprintf("The credential uses policy version %s, while the current version of the template is %s. "+
+				"Update the IAM policy with the celerdatabyoc_aws_%s_credential_policy data source and set `policy_version` to its `version`.",
+				policyVersion, latestVersion, kind),
+		},
+	}
+}
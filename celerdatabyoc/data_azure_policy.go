@@ -0,0 +1,266 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/csp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAzureDeploymentCredentialRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAzureDeploymentCredentialRoleRead,
+		Schema: map[string]*schema.Schema{
+			"subscription_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"scope": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"actions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"data_actions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAzureDeploymentCredentialRoleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*client.CelerdataClient)
+	cspAPI := csp.NewCspAPI(c)
+	resp, err := cspAPI.Get(ctx, &csp.GetReq{
+		CspName: cluster.CSP_AZURE,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := map[string]string{
+		"subscription-id":     d.Get("subscription_id").(string),
+		"resource-group-name": d.Get("resource_group_name").(string),
+	}
+	definition, err := renderPolicyTemplate("azure deployment credential role", resp.Csp.DeployCredPolicy, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	role, err := parseAzureRoleDefinition(definition)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("azure_deployment_credential:%s/%s", params["subscription-id"], params["resource-group-name"]))
+	d.Set("scope", azureResourceGroupScope(params))
+	d.Set("roles", []string{role.name()})
+	d.Set("actions", role.actions())
+	d.Set("data_actions", role.dataActions())
+	d.Set("json", definition)
+	d.Set("version", resp.Csp.DeployCredPolicyVersion)
+	return nil
+}
+
+func dataSourceAzureDataCredentialRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAzureDataCredentialRoleRead,
+		Schema: map[string]*schema.Schema{
+			"subscription_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"container_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"scope": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"actions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"data_actions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAzureDataCredentialRoleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*client.CelerdataClient)
+	cspAPI := csp.NewCspAPI(c)
+	resp, err := cspAPI.Get(ctx, &csp.GetReq{
+		CspName: cluster.CSP_AZURE,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := map[string]string{
+		"subscription-id":      d.Get("subscription_id").(string),
+		"resource-group-name":  d.Get("resource_group_name").(string),
+		"storage-account-name": d.Get("storage_account_name").(string),
+		"container-name":       d.Get("container_name").(string),
+	}
+	definition, err := renderPolicyTemplate("azure data credential role", resp.Csp.DataCredPolicy, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	role, err := parseAzureRoleDefinition(definition)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("azure_data_credential:%s/%s/%s/%s", params["subscription-id"], params["resource-group-name"],
+		params["storage-account-name"], params["container-name"]))
+	d.Set("scope", azureResourceGroupScope(params))
+	d.Set("roles", []string{role.name()})
+	d.Set("actions", role.actions())
+	d.Set("data_actions", role.dataActions())
+	d.Set("json", definition)
+	d.Set("version", resp.Csp.DataCredPolicyVersion)
+	return nil
+}
+
+func azureResourceGroupScope(params map[string]string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", params["subscription-id"], params["resource-group-name"])
+}
+
+var policyPlaceholder = regexp.MustCompile(`<[A-Za-z0-9_-]+>`)
+
+// renderPolicyTemplate replaces the <key> placeholders of a policy template returned by the CSP API. It fails if
+// CelerData doesn't provide the template or if the template has placeholders that params don't fill.
+func renderPolicyTemplate(name string, template string, params map[string]string) (string, error) {
+	if len(strings.TrimSpace(template)) == 0 {
+		return "", fmt.Errorf("CelerData doesn't provide the %s", name)
+	}
+	for k, v := range params {
+		template = strings.Replace(template, fmt.Sprintf("<%s>", k), v, -1)
+	}
+	if left := policyPlaceholder.FindAllString(template, -1); len(left) > 0 {
+		return "", fmt.Errorf("the %s has unknown placeholders: %v", name, left)
+	}
+	return template, nil
+}
+
+// azureRoleDefinition is an Azure custom role definition, either in the format of the Azure CLI or in the one of the
+// ARM API. The field names are matched case-insensitively.
+type azureRoleDefinition struct {
+	Name        string   `json:"name"`
+	RoleName    string   `json:"roleName"`
+	Actions     []string `json:"actions"`
+	DataActions []string `json:"dataActions"`
+	Properties  *struct {
+		RoleName    string `json:"roleName"`
+		Permissions []struct {
+			Actions     []string `json:"actions"`
+			DataActions []string `json:"dataActions"`
+		} `json:"permissions"`
+	} `json:"properties"`
+}
+
+func parseAzureRoleDefinition(definition string) (*azureRoleDefinition, error) {
+	role := &azureRoleDefinition{}
+	if err := json.Unmarshal([]byte(definition), role); err != nil {
+		return nil, fmt.Errorf("failed to parse the azure role definition: %v", err)
+	}
+	if len(role.name()) == 0 {
+		return nil, fmt.Errorf("the azure role definition has no name")
+	}
+	if len(role.actions()) == 0 && len(role.dataActions()) == 0 {
+		return nil, fmt.Errorf("the azure role definition %s has no actions", role.name())
+	}
+	return role, nil
+}
+
+func (r *azureRoleDefinition) name() string {
+	if r.Properties != nil && len(r.Properties.RoleName) > 0 {
+		return r.Properties.RoleName
+	}
+	if len(r.RoleName) > 0 {
+		return r.RoleName
+	}
+	return r.Name
+}
+
+func (r *azureRoleDefinition) actions() []string {
+	actions := append([]string{}, r.Actions...)
+	if r.Properties != nil {
+		for _, v := range r.Properties.Permissions {
+			actions = append(actions, v.Actions...)
+		}
+	}
+	return actions
+}
+
+func (r *azureRoleDefinition) dataActions() []string {
+	dataActions := append([]string{}, r.DataActions...)
+	if r.Properties != nil {
+		for _, v := range r.Properties.Permissions {
+			dataActions = append(dataActions, v.DataActions...)
+		}
+	}
+	return dataActions
+}
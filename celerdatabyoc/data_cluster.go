@@ -0,0 +1,343 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceCluster() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClusterRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"cluster_id", "cluster_name"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"cluster_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"cluster_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cluster_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cluster_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"csp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deployment_credential_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"data_credential_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"network_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"free_tier": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"query_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"idle_suspend_interval": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"resource_tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"coordinator_node": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataClusterModuleSchema(),
+			},
+			"compute_node": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The compute nodes of a classic cluster. Elastic clusters expose them per warehouse.",
+				Elem:        dataClusterModuleSchema(),
+			},
+			"warehouses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_default_warehouse": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"compute_node": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     dataClusterModuleSchema(),
+						},
+						"distribution_policy": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"specify_az": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"idle_suspend_interval": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"resource_tags": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"nlb_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nlb_endpoint_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataClusterModuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"instance_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"node_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ami_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"arch": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"os": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_instance_store": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"vol_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vol_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"iops": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"throughput": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+
+	clusterId := d.Get("cluster_id").(string)
+	if len(clusterId) == 0 {
+		id, err := findClusterIdByName(ctx, clusterAPI, d.Get("cluster_name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		clusterId = id
+	}
+
+	log.Printf("[DEBUG] get cluster, cluster[%s]", clusterId)
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		log.Printf("[ERROR] get cluster failed, err: %v", err)
+		return diag.FromErr(err)
+	}
+	if resp.Cluster == nil || resp.Cluster.ClusterState == cluster.ClusterStateReleased {
+		return diag.FromErr(fmt.Errorf("cluster %s not found", clusterId))
+	}
+
+	info := resp.Cluster
+	d.SetId(info.ClusterID)
+	d.Set("cluster_id", info.ClusterID)
+	d.Set("cluster_name", info.ClusterName)
+	d.Set("cluster_state", string(info.ClusterState))
+	d.Set("cluster_version", info.ClusterVersion)
+	d.Set("cluster_type", string(info.ClusterType))
+	d.Set("csp", info.Csp)
+	d.Set("region", info.Region)
+	d.Set("deployment_credential_id", info.DeployCredID)
+	d.Set("data_credential_id", info.DataCredID)
+	d.Set("network_id", info.NetIfaceID)
+	d.Set("free_tier", info.FreeTier)
+	d.Set("query_port", info.QueryPort)
+	d.Set("idle_suspend_interval", info.IdleSuspendInterval)
+	d.Set("resource_tags", info.Tags)
+	d.Set("coordinator_node", flattenClusterModule(info.FeModule))
+	if info.ClusterType == cluster.ClusterTypeClassic {
+		d.Set("compute_node", flattenClusterModule(info.BeModule))
+	}
+
+	warehouses := make([]interface{}, 0, len(info.Warehouses))
+	for _, wh := range info.Warehouses {
+		if wh.Deleted {
+			continue
+		}
+		whMap := map[string]interface{}{
+			"id":                    wh.Id,
+			"name":                  wh.Name,
+			"state":                 string(wh.State),
+			"is_default_warehouse":  wh.IsDefaultWarehouse,
+			"compute_node":          flattenClusterModule(wh.Module),
+			"distribution_policy":   wh.DistributionPolicyStr,
+			"specify_az":            wh.SpecifyAZ,
+			"idle_suspend_interval": 0,
+			"resource_tags":         wh.Tags,
+		}
+		if !wh.IsDefaultWarehouse {
+			idleConfigResp, err := clusterAPI.GetWarehouseIdleConfig(ctx, &cluster.GetWarehouseIdleConfigReq{
+				WarehouseId: wh.Id,
+			})
+			if err != nil {
+				log.Printf("[ERROR] Query warehouse idle suspend config failed, warehouseId:%s", wh.Id)
+				return diag.FromErr(err)
+			}
+			if idleConfig := idleConfigResp.Config; idleConfig != nil && idleConfig.State {
+				whMap["idle_suspend_interval"] = idleConfig.IntervalMs / 1000 / 60
+			}
+		}
+		warehouses = append(warehouses, whMap)
+	}
+	d.Set("warehouses", warehouses)
+
+	endpoints, err := clusterAPI.GetClusterEndpoints(ctx, &cluster.GetClusterEndpointsReq{
+		ClusterId: clusterId,
+	})
+	if err != nil {
+		log.Printf("[ERROR] get cluster endpoints failed, err: %v", err)
+		return diag.FromErr(err)
+	}
+	if endpoints.State == cluster.DomainAllocateStateSucceeded {
+		d.Set("endpoints", endpoints.List)
+	} else {
+		d.Set("endpoints", nil)
+	}
+	return nil
+}
+
+// findClusterIdByName returns the ID of the only cluster named name.
+func findClusterIdByName(ctx context.Context, clusterAPI cluster.IClusterAPI, name string) (string, error) {
+	resp, err := clusterAPI.ListCluster(ctx)
+	if err != nil {
+		log.Printf("[ERROR] list account cluster failed, err: %v", err)
+		return "", err
+	}
+
+	item, err := findOneByName("cluster", "cluster_id", name, resp.List,
+		func(v *cluster.ClusterInfo) string { return v.ClusterName },
+		func(v *cluster.ClusterInfo) string { return v.ClusterId })
+	if err != nil {
+		return "", err
+	}
+	return item.ClusterId, nil
+}
+
+func flattenClusterModule(module *cluster.Module) []interface{} {
+	if module == nil {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"instance_type":     module.InstanceType,
+			"node_count":        module.Num,
+			"ami_id":            module.AmiId,
+			"arch":              module.Arch,
+			"os":                module.Os,
+			"is_instance_store": module.IsInstanceStore,
+			"vol_number":        module.VmVolNum,
+			"vol_size":          module.VmVolSizeGB,
+			"iops":              module.Iops,
+			"throughput":        module.Throughput,
+		},
+	}
+}
@@ -0,0 +1,256 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	endpointNetworkMethodPublic  = "Public"
+	endpointNetworkMethodPrivate = "Private"
+
+	// The API doesn't return the HTTP and the Arrow Flight ports of the endpoints, so the URLs are built from
+	// assumptions: the load balancer of the cluster serves HTTPS on 443, which is why the query port can't be 443,
+	// and it forwards the arrow_flight_port of the FE in plain gRPC. The arrow_flight_port is read from the FE
+	// configs of the cluster, and defaults to the one of the FE.
+	endpointHttpsPort              = 443
+	endpointArrowFlightPortKey     = "arrow_flight_port"
+	endpointDefaultArrowFlightPort = 9408
+)
+
+// Unlike the celerdatabyoc_cluster_endpoints resource, this data source never allocates endpoints, it only
+// waits for an ongoing allocation to finish.
+func dataSourceClusterEndpoints() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClusterEndpointsRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"network_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The endpoint used to build the connection strings. Defaults to the public endpoint if there's one, otherwise the private endpoint.",
+				ValidateFunc: validation.StringInSlice([]string{endpointNetworkMethodPublic, endpointNetworkMethodPrivate}, false),
+			},
+			"database": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"user": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "admin",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"nlb_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nlb_endpoint_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"public_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"private_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"query_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"mysql_dsn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The DSN in the format of the Go MySQL driver, without the password.",
+			},
+			"jdbc_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"http_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"arrow_flight_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Arrow Flight SQL URL, empty if Arrow Flight is disabled on the cluster.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(common.DefaultWaitTimeout),
+		},
+	}
+}
+
+func dataSourceClusterEndpointsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+	clusterId := d.Get("cluster_id").(string)
+
+	log.Printf("[DEBUG] get cluster, cluster[%s]", clusterId)
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		log.Printf("[ERROR] get cluster failed, err: %v", err)
+		return diag.FromErr(err)
+	}
+	if resp.Cluster == nil || resp.Cluster.ClusterState == cluster.ClusterStateReleased {
+		return diag.FromErr(fmt.Errorf("cluster %s not found", clusterId))
+	}
+
+	stateResp, err := WaitClusterEndpointsStateChangeComplete(ctx, &waitEndpointsStateReq{
+		clusterAPI: clusterAPI,
+		clusterId:  clusterId,
+		timeout:    d.Timeout(schema.TimeoutRead),
+		pendingStates: []string{
+			strconv.Itoa(int(cluster.DomainAllocateStateOngoing)),
+		},
+		targetStates: []string{
+			strconv.Itoa(int(cluster.DomainAllocateStateUnknown)),
+			strconv.Itoa(int(cluster.DomainAllocateStateSucceeded)),
+			strconv.Itoa(int(cluster.DomainAllocateStateFailed)),
+		},
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("waiting for the endpoints of cluster (%s) to be allocated: %s", clusterId, err))
+	}
+
+	switch stateResp.State {
+	case cluster.DomainAllocateStateSucceeded:
+	case cluster.DomainAllocateStateFailed:
+		return diag.FromErr(fmt.Errorf("failed to allocate the endpoints of cluster %s", clusterId))
+	default:
+		return diag.FromErr(fmt.Errorf("the endpoints of cluster %s are not allocated, use the celerdatabyoc_cluster_endpoints resource to allocate them", clusterId))
+	}
+
+	var public, private *cluster.EndpointsInfo
+	for _, v := range stateResp.List {
+		switch v.NetworkMethod {
+		case endpointNetworkMethodPublic:
+			public = v
+		case endpointNetworkMethodPrivate:
+			private = v
+		}
+	}
+
+	networkMethod := d.Get("network_method").(string)
+	var endpoint *cluster.EndpointsInfo
+	switch networkMethod {
+	case endpointNetworkMethodPublic:
+		endpoint = public
+	case endpointNetworkMethodPrivate:
+		endpoint = private
+	default:
+		endpoint = public
+		if endpoint == nil {
+			endpoint = private
+		}
+	}
+	if endpoint == nil {
+		if len(networkMethod) == 0 {
+			return diag.FromErr(fmt.Errorf("cluster %s has no endpoints", clusterId))
+		}
+		return diag.FromErr(fmt.Errorf("cluster %s has no %s endpoint", clusterId, networkMethod))
+	}
+
+	arrowFlight, err := clusterAPI.GetClusterArrowFlight(ctx, &cluster.GetClusterArrowFlightReq{ClusterId: clusterId})
+	if err != nil {
+		log.Printf("[ERROR] get cluster arrow flight failed, err: %v", err)
+		return diag.FromErr(err)
+	}
+
+	d.SetId(clusterId)
+	d.Set("endpoints", stateResp.List)
+	d.Set("public_host", "")
+	if public != nil {
+		d.Set("public_host", public.Host)
+	}
+	d.Set("private_host", "")
+	if private != nil {
+		d.Set("private_host", private.Host)
+	}
+
+	host := endpoint.Host
+	port := endpoint.Port
+	database := d.Get("database").(string)
+	d.Set("network_method", endpoint.NetworkMethod)
+	d.Set("host", host)
+	d.Set("query_port", port)
+	d.Set("mysql_dsn", fmt.Sprintf("%s@tcp(%s:%d)/%s", d.Get("user").(string), host, port, database))
+	d.Set("jdbc_url", fmt.Sprintf("jdbc:mysql://%s:%d/%s", host, port, database))
+	d.Set("http_url", fmt.Sprintf("https://%s:%d", host, endpointHttpsPort))
+	d.Set("arrow_flight_url", "")
+	if arrowFlight.Enabled {
+		arrowFlightPort, err := getArrowFlightPort(ctx, clusterAPI, clusterId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("arrow_flight_url", fmt.Sprintf("grpc://%s:%d", host, arrowFlightPort))
+	}
+	return nil
+}
+
+// getArrowFlightPort returns the arrow_flight_port of the FE configs of the cluster, or the default one if it's
+// not configured.
+func getArrowFlightPort(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string) (int, error) {
+	configsResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
+		ClusterID:  clusterId,
+		ConfigType: cluster.CustomConfigTypeFE,
+	})
+	if err != nil {
+		log.Printf("[ERROR] query cluster custom config failed, err:%+v", err)
+		return 0, err
+	}
+
+	v, ok := configsResp.Configs[endpointArrowFlightPortKey]
+	if !ok {
+		return endpointDefaultArrowFlightPort, nil
+	}
+	port, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s of cluster %s: %s", endpointArrowFlightPortKey, clusterId, v)
+	}
+	return port, nil
+}
@@ -0,0 +1,227 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"log"
+	"strings"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceClusterVersions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClusterVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"csp": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{cluster.CSP_AWS, cluster.CSP_AZURE, cluster.CSP_GOOGLE}, false),
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"arch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the base images of this architecture, for example `x86_64` or `arm64`.",
+			},
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Check whether this cluster can be upgraded to each version.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"include_eol": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"current_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"release_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"eol_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_eol": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"upgradable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"base_images": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"os": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"arch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"release_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"eol_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_eol": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceClusterVersionsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+
+	csp := d.Get("csp").(string)
+	region := d.Get("region").(string)
+	arch := d.Get("arch").(string)
+	clusterId := d.Get("cluster_id").(string)
+	includeEol := d.Get("include_eol").(bool)
+
+	currentVersion := ""
+	if len(clusterId) > 0 {
+		log.Printf("[DEBUG] get cluster, cluster[%s]", clusterId)
+		resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+		if err != nil {
+			log.Printf("[ERROR] get cluster failed, err: %v", err)
+			return diag.FromErr(err)
+		}
+		if resp.Cluster == nil || resp.Cluster.ClusterState == cluster.ClusterStateReleased {
+			return diag.FromErr(fmt.Errorf("cluster %s not found", clusterId))
+		}
+		if resp.Cluster.Csp != csp || resp.Cluster.Region != region {
+			return diag.FromErr(fmt.Errorf("cluster %s is deployed in %s/%s, not in %s/%s", clusterId, resp.Cluster.Csp, resp.Cluster.Region, csp, region))
+		}
+		currentVersion = resp.Cluster.ClusterVersion
+	}
+
+	resp, err := clusterAPI.ListClusterVersions(ctx, &cluster.ListClusterVersionsReq{
+		Csp:       csp,
+		Region:    region,
+		Arch:      arch,
+		ClusterId: clusterId,
+	})
+	if err != nil {
+		log.Printf("[ERROR] list cluster versions failed, err: %v", err)
+		return diag.FromErr(err)
+	}
+
+	defaultVersion := ""
+	versions := make([]interface{}, 0, len(resp.Versions))
+	for _, v := range resp.Versions {
+		if v.IsDefault {
+			defaultVersion = v.Version
+		}
+		if v.IsEol && !includeEol {
+			continue
+		}
+		versions = append(versions, map[string]interface{}{
+			"version":      v.Version,
+			"release_date": v.ReleaseDate,
+			"eol_date":     v.EolDate,
+			"is_eol":       v.IsEol,
+			"is_default":   v.IsDefault,
+			"upgradable":   len(clusterId) > 0 && v.Upgradable,
+		})
+	}
+
+	baseImages := make([]interface{}, 0, len(resp.BaseImages))
+	for _, v := range resp.BaseImages {
+		if (len(arch) > 0 && v.Arch != arch) || (v.IsEol && !includeEol) {
+			continue
+		}
+		baseImages = append(baseImages, map[string]interface{}{
+			"os":           v.OS,
+			"arch":         v.Arch,
+			"description":  v.Description,
+			"release_date": v.ReleaseDate,
+			"eol_date":     v.EolDate,
+			"is_eol":       v.IsEol,
+			"is_default":   v.IsDefault,
+		})
+	}
+
+	filters := []string{csp, region, arch, clusterId, fmt.Sprint(includeEol)}
+	d.SetId(fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(filters, "\n")))))
+	d.Set("current_version", currentVersion)
+	d.Set("default_version", defaultVersion)
+	d.Set("versions", versions)
+	d.Set("base_images", baseImages)
+	return nil
+}
+
+// listBaseImageOs returns the OS of the base images that clusters of csp/region/arch can be deployed with.
+func listBaseImageOs(ctx context.Context, clusterAPI cluster.IClusterAPI, csp, region, arch string) ([]string, error) {
+	resp, err := clusterAPI.ListClusterVersions(ctx, &cluster.ListClusterVersionsReq{
+		Csp:    csp,
+		Region: region,
+		Arch:   arch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the base images of %s/%s: %s", csp, region, err.Error())
+	}
+
+	ret := make([]string, 0, len(resp.BaseImages))
+	for _, v := range resp.BaseImages {
+		if v.Arch == arch && !v.IsEol {
+			ret = append(ret, v.OS)
+		}
+	}
+	return ret, nil
+}
@@ -2,18 +2,67 @@ package celerdatabyoc
 
 import (
 	"context"
+	"crypto/md5"
+	"fmt"
 	"log"
+	"regexp"
+	"sort"
+	"strings"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func dataSourceClusters() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceClustersRead,
 		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"cluster_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(cluster.ClusterTypeElasic), string(cluster.ClusterTypeClassic)}, true),
+			},
+			"csp": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{cluster.CSP_AWS, cluster.CSP_AZURE, cluster.CSP_GOOGLE}, true),
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"cluster_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(cluster.ClusterStateDeploying),
+					string(cluster.ClusterStateRunning),
+					string(cluster.ClusterStateScaling),
+					string(cluster.ClusterStateAbnormal),
+					string(cluster.ClusterStateSuspending),
+					string(cluster.ClusterStateSuspended),
+					string(cluster.ClusterStateResuming),
+					string(cluster.ClusterStateReleasing),
+					string(cluster.ClusterStateUpdating),
+				}, true),
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags that the clusters must have. An empty value matches any value of the tag.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"clusters": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -36,6 +85,31 @@ func dataSourceClusters() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"cluster_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"csp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"warehouse_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"resource_tags": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
 					},
 				},
 			},
@@ -54,7 +128,108 @@ func dataSourceClustersRead(ctx context.Context, d *schema.ResourceData, meta an
 		return diag.FromErr(err)
 	}
 
-	d.Set("clusters", resp.List)
-	d.SetId("_")
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	clusterType := d.Get("cluster_type").(string)
+	csp := d.Get("csp").(string)
+	region := d.Get("region").(string)
+	clusterState := d.Get("cluster_state").(string)
+	tags := d.Get("tags").(map[string]interface{})
+
+	clusters := make([]interface{}, 0, len(resp.List))
+	for _, item := range resp.List {
+		// Filter on the fields of the list first, so only the matching clusters are fetched.
+		if nameRegex != nil && !nameRegex.MatchString(item.ClusterName) {
+			continue
+		}
+		if len(clusterType) > 0 && !strings.EqualFold(clusterType, item.ClusterType) {
+			continue
+		}
+
+		// The list only contains the basic information, the rest comes from the cluster itself.
+		clusterResp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: item.ClusterId})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				continue
+			}
+			log.Printf("[ERROR] get cluster failed, clusterId:%s err: %v", item.ClusterId, err)
+			return diag.FromErr(err)
+		}
+		info := clusterResp.Cluster
+		if info == nil || info.ClusterState == cluster.ClusterStateReleased {
+			continue
+		}
+		if len(csp) > 0 && !strings.EqualFold(csp, info.Csp) {
+			continue
+		}
+		if len(region) > 0 && region != info.Region {
+			continue
+		}
+		if len(clusterState) > 0 && !strings.EqualFold(clusterState, string(info.ClusterState)) {
+			continue
+		}
+		if !clusterTagsMatch(info.Tags, tags) {
+			continue
+		}
+
+		warehouseCount := 0
+		for _, wh := range info.Warehouses {
+			if !wh.Deleted {
+				warehouseCount++
+			}
+		}
+		clusters = append(clusters, map[string]interface{}{
+			"cluster_id":      item.ClusterId,
+			"cluster_name":    item.ClusterName,
+			"cluster_version": item.ClusterVersion,
+			"cluster_type":    item.ClusterType,
+			"cluster_state":   string(info.ClusterState),
+			"csp":             info.Csp,
+			"region":          info.Region,
+			"warehouse_count": warehouseCount,
+			"created_at":      item.CreatedAt,
+			"resource_tags":   info.Tags,
+		})
+	}
+
+	d.Set("clusters", clusters)
+	d.SetId(clustersFilterId(d))
 	return nil
 }
+
+func clusterTagsMatch(clusterTags map[string]string, tags map[string]interface{}) bool {
+	for k, v := range tags {
+		tagValue, ok := clusterTags[k]
+		if !ok {
+			return false
+		}
+		if len(v.(string)) > 0 && v.(string) != tagValue {
+			return false
+		}
+	}
+	return true
+}
+
+// clustersFilterId returns an ID that is the same for data sources with the same filters.
+func clustersFilterId(d *schema.ResourceData) string {
+	filters := []string{
+		d.Get("name_regex").(string),
+		strings.ToUpper(d.Get("cluster_type").(string)),
+		strings.ToLower(d.Get("csp").(string)),
+		d.Get("region").(string),
+		strings.ToLower(d.Get("cluster_state").(string)),
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		filters = append(filters, fmt.Sprintf("%s=%s", k, tags[k].(string)))
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(filters, "\n"))))
+}
@@ -0,0 +1,106 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/credential"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDataCredential() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDataCredentialRead,
+		Schema: map[string]*schema.Schema{
+			"credential_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"credential_id", "name"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"csp": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{cluster.CSP_AWS, cluster.CSP_AZURE, cluster.CSP_GOOGLE}, false),
+			},
+			"role_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_profile_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bucket_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"policy_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDataCredentialRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	credCli := credential.NewCredentialAPI(c)
+	csp := d.Get("csp").(string)
+
+	idOf := func(v *credential.DataCredential) string { return v.BizID }
+
+	var cred *credential.DataCredential
+	if credID := d.Get("credential_id").(string); len(credID) > 0 {
+		log.Printf("[DEBUG] get data credential, id[%s]", credID)
+		resp, err := credCli.GetDataCredential(ctx, credID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(resp.DataCred.BizID) == 0 {
+			return diag.FromErr(fmt.Errorf("data credential %s not found", credID))
+		}
+		cred = &resp.DataCred
+
+		// The data credential doesn't carry its csp.
+		list, err := credCli.ListDataCredentials(ctx, &credential.ListCredentialsReq{Csp: csp})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := checkListed("data credential", credID, csp, list.List, idOf); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		log.Printf("[DEBUG] list data credentials, csp[%s]", csp)
+		list, err := credCli.ListDataCredentials(ctx, &credential.ListCredentialsReq{Csp: csp})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		cred, err = findOneByName("data credential", "credential_id", d.Get("name").(string), list.List,
+			func(v *credential.DataCredential) string { return v.Name }, idOf)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(cred.BizID)
+	d.Set("credential_id", cred.BizID)
+	d.Set("name", cred.Name)
+	d.Set("role_arn", cred.RoleArn)
+	d.Set("instance_profile_arn", cred.InstanceProfileArn)
+	d.Set("bucket_name", cred.BucketName)
+	d.Set("policy_version", cred.PolicyVersion)
+	return nil
+}
@@ -0,0 +1,174 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/credential"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Deployment credentials of Azure are AK/SK credentials, those of AWS and GCP are role credentials.
+func dataSourceDeploymentCredential() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDeploymentCredentialRead,
+		Schema: map[string]*schema.Schema{
+			"credential_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"credential_id", "name"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"csp": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{cluster.CSP_AWS, cluster.CSP_AZURE, cluster.CSP_GOOGLE}, false),
+			},
+			"role_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"external_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"trust_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"policy_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"csp_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"csp_org_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDeploymentCredentialRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	credCli := credential.NewCredentialAPI(c)
+	csp := d.Get("csp").(string)
+
+	var err error
+	if csp == cluster.CSP_AZURE {
+		err = readDeploymentAkSkCredential(ctx, credCli, d)
+	} else {
+		err = readDeploymentRoleCredential(ctx, credCli, d)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func readDeploymentRoleCredential(ctx context.Context, credCli credential.ICredentialAPI, d *schema.ResourceData) error {
+	csp := d.Get("csp").(string)
+	idOf := func(v *credential.DeploymentRoleCredential) string { return v.BizID }
+
+	var cred *credential.DeploymentRoleCredential
+	if credID := d.Get("credential_id").(string); len(credID) > 0 {
+		log.Printf("[DEBUG] get deployment role credential, id[%s]", credID)
+		resp, err := credCli.GetDeploymentRoleCredential(ctx, credID)
+		if err != nil {
+			return err
+		}
+		if resp.DeployRoleCred == nil || len(resp.DeployRoleCred.BizID) == 0 {
+			return fmt.Errorf("deployment credential %s not found", credID)
+		}
+		cred = resp.DeployRoleCred
+
+		// The role credentials of AWS and GCP share the endpoint, and the credential doesn't carry its csp.
+		list, err := credCli.ListDeploymentRoleCredentials(ctx, &credential.ListCredentialsReq{Csp: csp})
+		if err != nil {
+			return err
+		}
+		if err := checkListed("deployment credential", credID, csp, list.List, idOf); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("[DEBUG] list deployment role credentials, csp[%s]", csp)
+		list, err := credCli.ListDeploymentRoleCredentials(ctx, &credential.ListCredentialsReq{Csp: csp})
+		if err != nil {
+			return err
+		}
+		cred, err = findOneByName("deployment credential", "credential_id", d.Get("name").(string), list.List,
+			func(v *credential.DeploymentRoleCredential) string { return v.Name }, idOf)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(cred.BizID)
+	d.Set("credential_id", cred.BizID)
+	d.Set("name", cred.Name)
+	d.Set("role_arn", cred.RoleArn)
+	d.Set("external_id", cred.ExternalId)
+	d.Set("trust_account_id", cred.TrustAccountId)
+	d.Set("policy_version", cred.PolicyVersion)
+	return nil
+}
+
+func readDeploymentAkSkCredential(ctx context.Context, credCli credential.ICredentialAPI, d *schema.ResourceData) error {
+	csp := d.Get("csp").(string)
+	idOf := func(v *credential.DeploymentAkSkCredential) string { return v.BizID }
+
+	var cred *credential.DeploymentAkSkCredential
+	if credID := d.Get("credential_id").(string); len(credID) > 0 {
+		log.Printf("[DEBUG] get deployment ak/sk credential, id[%s]", credID)
+		resp, err := credCli.GetDeploymentAkSkCredential(ctx, credID)
+		if err != nil {
+			return err
+		}
+		if resp.DeployAkSkCred == nil || len(resp.DeployAkSkCred.BizID) == 0 {
+			return fmt.Errorf("deployment credential %s not found", credID)
+		}
+		cred = resp.DeployAkSkCred
+
+		list, err := credCli.ListDeploymentAkSkCredentials(ctx, &credential.ListCredentialsReq{Csp: csp})
+		if err != nil {
+			return err
+		}
+		if err := checkListed("deployment credential", credID, csp, list.List, idOf); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("[DEBUG] list deployment ak/sk credentials, csp[%s]", csp)
+		list, err := credCli.ListDeploymentAkSkCredentials(ctx, &credential.ListCredentialsReq{Csp: csp})
+		if err != nil {
+			return err
+		}
+		cred, err = findOneByName("deployment credential", "credential_id", d.Get("name").(string), list.List,
+			func(v *credential.DeploymentAkSkCredential) string { return v.Name }, idOf)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The AK/SK and the ssh key are secrets, they're not exported.
+	d.SetId(cred.BizID)
+	d.Set("credential_id", cred.BizID)
+	d.Set("name", cred.Name)
+	d.Set("csp_account_id", cred.CspAccountId)
+	d.Set("csp_org_id", cred.CspOrgId)
+	return nil
+}
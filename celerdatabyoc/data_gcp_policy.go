@@ -0,0 +1,166 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/csp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceGcpDeploymentCredentialRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGcpDeploymentCredentialRoleRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"trust_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGcpDeploymentCredentialRoleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*client.CelerdataClient)
+	cspAPI := csp.NewCspAPI(c)
+	resp, err := cspAPI.Get(ctx, &csp.GetReq{
+		CspName: cluster.CSP_GOOGLE,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectId := d.Get("project_id").(string)
+	definition, err := renderPolicyTemplate("gcp deployment credential role", resp.Csp.DeployCredPolicy, map[string]string{
+		"project-id": projectId,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	role, err := parseGcpRoleDefinition(definition)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("gcp_deployment_credential:%s", projectId))
+	d.Set("permissions", role.IncludedPermissions)
+	d.Set("roles", role.Roles)
+	d.Set("trust_account_id", resp.Csp.TrustAccountId)
+	d.Set("json", definition)
+	d.Set("version", resp.Csp.DeployCredPolicyVersion)
+	return nil
+}
+
+func dataSourceGcpDataCredentialRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGcpDataCredentialRoleRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"condition_expression": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGcpDataCredentialRoleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*client.CelerdataClient)
+	cspAPI := csp.NewCspAPI(c)
+	resp, err := cspAPI.Get(ctx, &csp.GetReq{
+		CspName: cluster.CSP_GOOGLE,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectId := d.Get("project_id").(string)
+	bucket := d.Get("bucket").(string)
+	definition, err := renderPolicyTemplate("gcp data credential role", resp.Csp.DataCredPolicy, map[string]string{
+		"project-id":      projectId,
+		"gcs-bucket-name": bucket,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	role, err := parseGcpRoleDefinition(definition)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("gcp_data_credential:%s/%s", projectId, bucket))
+	d.Set("permissions", role.IncludedPermissions)
+	// Restricts the role binding to the bucket.
+	d.Set("condition_expression", fmt.Sprintf("resource.name.startsWith(\"projects/_/buckets/%s/\") || resource.name == \"projects/_/buckets/%s\"", bucket, bucket))
+	d.Set("json", definition)
+	d.Set("version", resp.Csp.DataCredPolicyVersion)
+	return nil
+}
+
+// gcpRoleDefinition is a GCP custom role definition in the format of `gcloud iam roles create --file`. Roles lists
+// the predefined roles to grant along with the custom role, if the definition has any.
+type gcpRoleDefinition struct {
+	IncludedPermissions []string `json:"includedPermissions"`
+	Roles               []string `json:"roles"`
+}
+
+func parseGcpRoleDefinition(definition string) (*gcpRoleDefinition, error) {
+	role := &gcpRoleDefinition{}
+	if err := json.Unmarshal([]byte(definition), role); err != nil {
+		return nil, fmt.Errorf("failed to parse the gcp role definition: %v", err)
+	}
+	if len(role.IncludedPermissions) == 0 {
+		return nil, fmt.Errorf("the gcp role definition has no permissions")
+	}
+	return role, nil
+}
@@ -0,0 +1,39 @@
+package celerdatabyoc
+
+import (
+	"fmt"
+)
+
+// The data sources look up an object either by ID or by name. Names aren't unique, so a lookup by name only
+// succeeds when exactly one object has the name, otherwise the user has to fall back to the ID.
+
+// findOneByName returns the only item of items named name. kind names the object in the errors, and idAttr is the
+// argument to use instead when several objects share the name.
+func findOneByName[T any](kind, idAttr, name string, items []T, nameOf, idOf func(T) string) (T, error) {
+	var found T
+	ids := make([]string, 0)
+	for _, v := range items {
+		if nameOf(v) == name {
+			found = v
+			ids = append(ids, idOf(v))
+		}
+	}
+	if len(ids) == 0 {
+		return found, fmt.Errorf("%s %s not found", kind, name)
+	}
+	if len(ids) > 1 {
+		return found, fmt.Errorf("found %d %ss named %s, use `%s` instead: %v", len(ids), kind, name, idAttr, ids)
+	}
+	return found, nil
+}
+
+// checkListed returns an error unless the object id is one of items, the objects listed for the csp. It checks the
+// csp of an object looked up by ID, when the object itself doesn't carry it.
+func checkListed[T any](kind, id, csp string, items []T, idOf func(T) string) error {
+	for _, v := range items {
+		if idOf(v) == id {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %s is not a %s %s", kind, id, csp, kind)
+}
@@ -0,0 +1,144 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/network"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNetwork() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkRead,
+		Schema: map[string]*schema.Schema{
+			"network_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"network_id", "name"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"csp": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{cluster.CSP_AWS, cluster.CSP_AZURE, cluster.CSP_GOOGLE}, false),
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_endpoint_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"multi_az": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"subnet_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"az_network_interfaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"az": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	networkCli := network.NewNetworkAPI(c)
+	csp := d.Get("csp").(string)
+
+	var net *network.Network
+	if netID := d.Get("network_id").(string); len(netID) > 0 {
+		log.Printf("[DEBUG] get network, id[%s]", netID)
+		resp, err := networkCli.GetNetwork(ctx, netID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if resp.Network == nil || len(resp.Network.BizID) == 0 {
+			return diag.FromErr(fmt.Errorf("network %s not found", netID))
+		}
+		net = resp.Network
+
+		list, err := networkCli.ListNetworks(ctx, &network.ListNetworksReq{Csp: csp})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := checkListed("network", netID, csp, list.List, func(v *network.Network) string { return v.BizID }); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		log.Printf("[DEBUG] list networks, csp[%s]", csp)
+		resp, err := networkCli.ListNetworks(ctx, &network.ListNetworksReq{Csp: csp})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		net, err = findOneByName("network", "network_id", name, resp.List,
+			func(v *network.Network) string { return v.Name },
+			func(v *network.Network) string { return v.BizID })
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	subnetIds := make([]string, 0, len(net.AZNetWorkInterfaces))
+	interfaces := make([]interface{}, 0, len(net.AZNetWorkInterfaces))
+	for _, v := range net.AZNetWorkInterfaces {
+		subnetIds = append(subnetIds, v.SubnetId)
+		interfaces = append(interfaces, map[string]interface{}{
+			"az":        v.Az,
+			"subnet_id": v.SubnetId,
+		})
+	}
+
+	d.SetId(net.BizID)
+	d.Set("network_id", net.BizID)
+	d.Set("name", net.Name)
+	d.Set("region", net.RegionId)
+	d.Set("subnet_id", net.SubnetId)
+	d.Set("security_group_id", net.SecurityGroupId)
+	d.Set("vpc_endpoint_id", net.VpcEndpointId)
+	d.Set("multi_az", net.MultiAz)
+	d.Set("subnet_ids", subnetIds)
+	d.Set("az_network_interfaces", interfaces)
+	return nil
+}
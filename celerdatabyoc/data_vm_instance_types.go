@@ -0,0 +1,214 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceVmInstanceTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVmInstanceTypesRead,
+		Schema: map[string]*schema.Schema{
+			"csp": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{cluster.CSP_AWS, cluster.CSP_AZURE, cluster.CSP_GOOGLE}, false),
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"process_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "FE (or COORDINATOR) for coordinator nodes, BE (or COMPUTE) for compute nodes.",
+				ValidateFunc: validation.StringInSlice([]string{"FE", "BE", "COORDINATOR", "COMPUTE"}, true),
+			},
+			"arch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"instance_store": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"min_vcpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_memory_gb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"instance_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"process_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vcpu": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory_gb": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"arch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_instance_store": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"max_data_disk_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"volume_category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_vol_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"default_vol_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVmInstanceTypesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	vmCatalog := cluster.SharedVmCatalog(c)
+
+	csp := d.Get("csp").(string)
+	region := d.Get("region").(string)
+	vmInfos, err := vmCatalog.List(ctx, csp, region)
+	if err != nil {
+		log.Printf("[ERROR] list vm instance types failed, err: %v", err)
+		return diag.FromErr(err)
+	}
+
+	processType := normalizeProcessType(d.Get("process_type").(string))
+	arch := d.Get("arch").(string)
+	minVcpu := d.Get("min_vcpu").(int)
+	minMemory := d.Get("min_memory_gb").(int)
+	instanceStore := d.GetRawConfig().GetAttr("instance_store")
+
+	filtered := make([]*cluster.VMInfo, 0, len(vmInfos))
+	for _, v := range vmInfos {
+		if len(processType) > 0 && !strings.EqualFold(processType, v.ProcessType) {
+			continue
+		}
+		if len(arch) > 0 && !strings.EqualFold(arch, v.Arch) {
+			continue
+		}
+		if !instanceStore.IsNull() && instanceStore.True() != v.IsInstanceStore {
+			continue
+		}
+		if int(v.CpuCores) < minVcpu || int(v.MemoryGb) < minMemory {
+			continue
+		}
+		filtered = append(filtered, v)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		if a.ProcessType != b.ProcessType {
+			return a.ProcessType > b.ProcessType
+		}
+		if a.CpuCores != b.CpuCores {
+			return a.CpuCores < b.CpuCores
+		}
+		if a.MemoryGb != b.MemoryGb {
+			return a.MemoryGb < b.MemoryGb
+		}
+		return a.VmCate < b.VmCate
+	})
+
+	instanceTypes := make([]interface{}, 0, len(filtered))
+	for _, v := range filtered {
+		instanceTypes = append(instanceTypes, flattenVmInfo(v))
+	}
+
+	filters := []string{csp, region, processType, strings.ToLower(arch), strconv.Itoa(minVcpu), strconv.Itoa(minMemory)}
+	if !instanceStore.IsNull() {
+		filters = append(filters, strconv.FormatBool(instanceStore.True()))
+	}
+	d.Set("instance_types", instanceTypes)
+	d.SetId(fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(filters, "\n")))))
+	return nil
+}
+
+func normalizeProcessType(processType string) string {
+	switch strings.ToUpper(processType) {
+	case "COORDINATOR":
+		return string(cluster.ClusterModuleTypeFE)
+	case "COMPUTE":
+		return string(cluster.ClusterModuleTypeBE)
+	}
+	return strings.ToUpper(processType)
+}
+
+// flattenVmInfo returns the vm instance type with the volume defaults of celerdatabyoc_elastic_cluster_v2,
+// capped by what the instance type supports. Instance store types don't take a volume config.
+func flattenVmInfo(v *cluster.VMInfo) map[string]interface{} {
+	volumeCate := ""
+	if len(v.VmVolumeInfos) > 0 {
+		volumeCate = v.VmVolumeInfos[0].VolumeCate
+	}
+
+	volNumber, volSize := 0, 0
+	if !v.IsInstanceStore {
+		volNumber, volSize = cluster.DefaultBeVolNumber, cluster.DefaultBeVolSize
+		if strings.EqualFold(v.ProcessType, string(cluster.ClusterModuleTypeFE)) {
+			volNumber, volSize = cluster.DefaultFeVolNumber, cluster.DefaultFeVolSize
+		}
+		if v.MaxDataDiskCount > 0 && volNumber > int(v.MaxDataDiskCount) {
+			volNumber = int(v.MaxDataDiskCount)
+		}
+	}
+
+	return map[string]interface{}{
+		"instance_type":       v.VmCate,
+		"process_type":        v.ProcessType,
+		"vcpu":                v.CpuCores,
+		"memory_gb":           v.MemoryGb,
+		"arch":                v.Arch,
+		"is_instance_store":   v.IsInstanceStore,
+		"max_data_disk_count": v.MaxDataDiskCount,
+		"volume_category":     volumeCate,
+		"default_vol_number":  volNumber,
+		"default_vol_size":    volSize,
+	}
+}
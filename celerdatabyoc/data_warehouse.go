@@ -0,0 +1,283 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataWarehouseSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"warehouse_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"is_default_warehouse": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"compute_node_size": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"compute_node_count": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"compute_node_is_instance_store": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"compute_node_volume_config": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vol_number": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+					"vol_size": {
+						Type:     schema.TypeInt,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"distribution_policy": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"specify_az": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"resume_with_cluster": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"idle_suspend_interval": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"auto_scaling_policy": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The auto-scaling policy in JSON, empty if auto scaling is disabled.",
+		},
+		"auto_scaling_min_size": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"auto_scaling_max_size": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"resource_tags": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func dataSourceWarehouse() *schema.Resource {
+	s := dataWarehouseSchema()
+	s["cluster_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+	}
+	s["warehouse_id"].Optional = true
+	s["warehouse_id"].ExactlyOneOf = []string{"warehouse_id", "name"}
+	s["warehouse_id"].ValidateFunc = validation.StringIsNotEmpty
+	s["name"].Optional = true
+	s["name"].ValidateFunc = validation.StringIsNotEmpty
+
+	return &schema.Resource{
+		ReadContext: dataSourceWarehouseRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceWarehouseRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+	clusterId := d.Get("cluster_id").(string)
+
+	warehouses, err := listClusterWarehouses(ctx, clusterAPI, clusterId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	whId := d.Get("warehouse_id").(string)
+	name := d.Get("name").(string)
+	var wh *cluster.Warehouse
+	for _, v := range warehouses {
+		if (len(whId) > 0 && v.Id == whId) || (len(whId) == 0 && v.Name == name) {
+			wh = v
+			break
+		}
+	}
+	if wh == nil {
+		if len(whId) == 0 {
+			whId = name
+		}
+		return diag.FromErr(fmt.Errorf("warehouse %s not found in cluster %s", whId, clusterId))
+	}
+
+	whMap, err := readWarehouseFacts(ctx, clusterAPI, wh)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(wh.Id)
+	for k, v := range whMap {
+		d.Set(k, v)
+	}
+	return nil
+}
+
+func dataSourceWarehouses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWarehousesRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"warehouses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: dataWarehouseSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWarehousesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+	clusterId := d.Get("cluster_id").(string)
+
+	warehouses, err := listClusterWarehouses(ctx, clusterAPI, clusterId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ret := make([]interface{}, 0, len(warehouses))
+	for _, wh := range warehouses {
+		whMap, err := readWarehouseFacts(ctx, clusterAPI, wh)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ret = append(ret, whMap)
+	}
+
+	d.SetId(clusterId)
+	d.Set("warehouses", ret)
+	return nil
+}
+
+// listClusterWarehouses returns the warehouses of the cluster that are not deleted.
+func listClusterWarehouses(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string) ([]*cluster.Warehouse, error) {
+	log.Printf("[DEBUG] get cluster, cluster[%s]", clusterId)
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		log.Printf("[ERROR] get cluster failed, err: %v", err)
+		return nil, err
+	}
+	if resp.Cluster == nil || resp.Cluster.ClusterState == cluster.ClusterStateReleased {
+		return nil, fmt.Errorf("cluster %s not found", clusterId)
+	}
+
+	ret := make([]*cluster.Warehouse, 0, len(resp.Cluster.Warehouses))
+	for _, wh := range resp.Cluster.Warehouses {
+		if !wh.Deleted {
+			ret = append(ret, wh)
+		}
+	}
+	return ret, nil
+}
+
+func readWarehouseFacts(ctx context.Context, clusterAPI cluster.IClusterAPI, wh *cluster.Warehouse) (map[string]interface{}, error) {
+	warehouseId := wh.Id
+
+	resp, err := clusterAPI.GetWarehouse(ctx, &cluster.GetWarehouseReq{WarehouseId: warehouseId})
+	if err != nil {
+		log.Printf("[ERROR] get warehouse failed, warehouseId:%s err: %v", warehouseId, err)
+		return nil, err
+	}
+	info := resp.Info
+
+	whMap := map[string]interface{}{
+		"warehouse_id":                   warehouseId,
+		"name":                           info.WarehouseName,
+		"state":                          info.State,
+		"is_default_warehouse":           info.IsDefault,
+		"compute_node_size":              info.VmCate,
+		"compute_node_count":             info.NodeCount,
+		"compute_node_is_instance_store": info.IsInstanceStore,
+		"distribution_policy":            info.DistributionPolicyStr,
+		"specify_az":                     info.SpecifyAZ,
+		"resume_with_cluster":            wh.ResumeWithCluster,
+		"idle_suspend_interval":          0,
+		"auto_scaling_policy":            "",
+		"auto_scaling_min_size":          0,
+		"auto_scaling_max_size":          0,
+		"resource_tags":                  wh.Tags,
+	}
+	if !info.IsInstanceStore {
+		whMap["compute_node_volume_config"] = []interface{}{
+			map[string]interface{}{
+				"vol_number": info.VmVolNum,
+				"vol_size":   info.VmVolSizeGB,
+			},
+		}
+	}
+
+	// The default warehouse has no idle config, it's suspended with the cluster.
+	if !info.IsDefault {
+		idleConfigResp, err := clusterAPI.GetWarehouseIdleConfig(ctx, &cluster.GetWarehouseIdleConfigReq{
+			WarehouseId: warehouseId,
+		})
+		if err != nil {
+			log.Printf("[ERROR] Query warehouse idle suspend config failed, warehouseId:%s", warehouseId)
+			return nil, err
+		}
+		if idleConfig := idleConfigResp.Config; idleConfig != nil && idleConfig.State {
+			whMap["idle_suspend_interval"] = idleConfig.IntervalMs / 1000 / 60
+		}
+	}
+
+	autoScalingConfigResp, err := clusterAPI.GetWarehouseAutoScalingConfig(ctx, &cluster.GetWarehouseAutoScalingConfigReq{
+		WarehouseId: warehouseId,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Query warehouse auto scaling config failed, warehouseId:%s", warehouseId)
+		return nil, err
+	}
+	if policy := autoScalingConfigResp.Policy; policy != nil && policy.State {
+		whMap["auto_scaling_policy"] = autoScalingPolicyJson(policy)
+		whMap["auto_scaling_min_size"] = policy.MinSize
+		whMap["auto_scaling_max_size"] = policy.MaxSize
+	}
+	return whMap, nil
+}
@@ -0,0 +1,118 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"log"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceWarehouseAutoScalingPolicy reads the policy a warehouse actually runs, in the same shape as the
+// celerdatabyoc_auto_scaling_policy resource.
+func dataSourceWarehouseAutoScalingPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWarehouseAutoScalingPolicyRead,
+		Schema: map[string]*schema.Schema{
+			"warehouse_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"min_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"max_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"auto_scaling_unit": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"policy_item": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"step_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"condition": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"duration_seconds": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"value": {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"policy_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceWarehouseAutoScalingPolicyRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	c := meta.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+	warehouseId := d.Get("warehouse_id").(string)
+
+	resp, err := clusterAPI.GetWarehouseAutoScalingConfig(ctx, &cluster.GetWarehouseAutoScalingConfigReq{
+		WarehouseId: warehouseId,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Query warehouse auto scaling config failed, warehouseId:%s", warehouseId)
+		return diag.FromErr(err)
+	}
+
+	d.SetId(warehouseId)
+	policy := resp.Policy
+	if policy == nil || !policy.State {
+		d.Set("enabled", false)
+		d.Set("min_size", 0)
+		d.Set("max_size", 0)
+		d.Set("auto_scaling_unit", "")
+		d.Set("policy_item", nil)
+		d.Set("policy_json", "")
+		return nil
+	}
+
+	NormalizeAutoScalingPolicy(policy)
+	d.Set("enabled", true)
+	d.Set("min_size", policy.MinSize)
+	d.Set("max_size", policy.MaxSize)
+	d.Set("auto_scaling_unit", autoScalingUnitToDisplay(policy.AutoScalingUnit))
+	d.Set("policy_item", FlattenAutoScalingPolicyItems(policy))
+	d.Set("policy_json", autoScalingPolicyJson(policy))
+	return nil
+}
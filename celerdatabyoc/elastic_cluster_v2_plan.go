@@ -0,0 +1,282 @@
+package celerdatabyoc
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The plugin SDK doesn't allow CustomizeDiff to return warning diagnostics, so the operations an update
+// will perform are planned into the computed `planned_operations` attribute, which `terraform plan` shows
+// next to the changes that cause them. The applied state must match the plan, so the attribute keeps the
+// operations of the last update until the next change is planned.
+
+const (
+	impactOnline                 = "online"
+	impactCoordinatorRestart     = "rolling restart of coordinator nodes"
+	impactComputeRestart         = "rolling restart of compute nodes"
+	impactAllRestart             = "rolling restart of all nodes"
+	impactCoordinatorNodeRemoval = "coordinator nodes are removed"
+	impactComputeNodeRemoval     = "compute nodes are removed, running queries on them fail"
+	impactComputeNodeRecreation  = "compute nodes are re-created in the new availability zones"
+	impactClusterDowntime        = "downtime, the cluster is unavailable until it's resumed"
+	impactWarehouseDowntime      = "downtime, the warehouse is unavailable until it's resumed"
+	impactWarehouseRelease       = "downtime, the warehouse is released"
+	impactScriptsRun             = "the scripts run on the running nodes"
+	impactRangerApplied          = "access control switches to the ranger config"
+	impactRangerCleared          = "access control falls back to the native privileges"
+)
+
+// planDiff is implemented by both *schema.ResourceDiff and *schema.ResourceData.
+type planDiff interface {
+	Get(key string) interface{}
+	HasChange(key string) bool
+	GetChange(key string) (interface{}, interface{})
+}
+
+type plannedOperation struct {
+	action string
+	impact string
+}
+
+func (o plannedOperation) String() string {
+	return fmt.Sprintf("%s (%s)", o.action, o.impact)
+}
+
+func (o plannedOperation) disruptive() bool {
+	return o.impact != impactOnline
+}
+
+// planElasticClusterV2Operations returns the operations resourceElasticClusterV2Update performs for the diff,
+// in the order they are performed.
+func planElasticClusterV2Operations(d planDiff, multiAz bool) []plannedOperation {
+	ops := make([]plannedOperation, 0)
+
+	if d.HasChange("scheduling_policy") {
+		ops = append(ops, plannedOperation{action: "update the scheduling policies", impact: impactOnline})
+	}
+
+	if d.HasChange("expected_cluster_state") && d.Get("expected_cluster_state").(string) == string(cluster.ClusterStateRunning) {
+		ops = append(ops, plannedOperation{action: "resume the cluster", impact: impactOnline})
+	}
+
+	if d.HasChange("init_scripts") {
+		ops = append(ops, plannedOperation{action: "update the init scripts of nodes deployed later", impact: impactOnline})
+	}
+
+	if d.HasChange("enabled_arrow_flight") {
+		action := "disable Arrow Flight"
+		if d.Get("enabled_arrow_flight").(bool) {
+			action = "enable Arrow Flight"
+		}
+		ops = append(ops, plannedOperation{action: action, impact: impactCoordinatorRestart})
+	}
+
+	if d.HasChange("coordinator_node_size") {
+		o, n := d.GetChange("coordinator_node_size")
+		ops = append(ops, plannedOperation{
+			action: fmt.Sprintf("resize coordinator nodes from %s to %s", o.(string), n.(string)),
+			impact: impactCoordinatorRestart,
+		})
+	}
+
+	if d.HasChange("coordinator_node_count") {
+		o, n := d.GetChange("coordinator_node_count")
+		if n.(int) > o.(int) {
+			ops = append(ops, plannedOperation{
+				action: fmt.Sprintf("scale out coordinator nodes from %d to %d", o.(int), n.(int)),
+				impact: impactOnline,
+			})
+		} else {
+			ops = append(ops, plannedOperation{
+				action: fmt.Sprintf("scale in coordinator nodes from %d to %d", o.(int), n.(int)),
+				impact: impactCoordinatorNodeRemoval,
+			})
+		}
+	}
+
+	if d.HasChange("coordinator_node_volume_config") {
+		ops = append(ops, plannedOperation{action: "modify the volumes of coordinator nodes", impact: impactOnline})
+	}
+
+	if d.HasChange("coordinator_node_configs") {
+		ops = append(ops, plannedOperation{action: "apply coordinator node configs", impact: impactCoordinatorRestart})
+	}
+
+	if d.HasChange("default_warehouse") {
+		o, n := d.GetChange("default_warehouse")
+		oldWh := o.([]interface{})[0].(map[string]interface{})
+		newWh := n.([]interface{})[0].(map[string]interface{})
+		ops = append(ops, planWarehouseOperations(oldWh, newWh, true, multiAz)...)
+	}
+
+	if d.HasChange("warehouse") {
+		o, n := d.GetChange("warehouse")
+
+		oldWhMap := make(map[string]map[string]interface{})
+		for _, v := range o.([]interface{}) {
+			whMap := v.(map[string]interface{})
+			oldWhMap[whMap["name"].(string)] = whMap
+		}
+		newWhMap := make(map[string]map[string]interface{})
+		for _, v := range n.([]interface{}) {
+			whMap := v.(map[string]interface{})
+			newWhMap[whMap["name"].(string)] = whMap
+		}
+
+		for _, v := range n.([]interface{}) {
+			newWh := v.(map[string]interface{})
+			whName := newWh["name"].(string)
+			if oldWh, ok := oldWhMap[whName]; ok {
+				ops = append(ops, planWarehouseOperations(oldWh, newWh, false, multiAz)...)
+			} else {
+				ops = append(ops, plannedOperation{action: fmt.Sprintf("create warehouse[%s]", whName), impact: impactOnline})
+			}
+		}
+
+		for _, v := range o.([]interface{}) {
+			whName := v.(map[string]interface{})["name"].(string)
+			if _, ok := newWhMap[whName]; !ok {
+				ops = append(ops, plannedOperation{action: fmt.Sprintf("release warehouse[%s]", whName), impact: impactWarehouseRelease})
+			}
+		}
+	}
+
+	if d.HasChange("scripts") {
+		ops = append(ops, plannedOperation{action: "run the added, changed and rerun scripts", impact: impactScriptsRun})
+	}
+
+	if d.HasChange("ranger_config_id") {
+		if rangerConfigId := d.Get("ranger_config_id").(string); len(rangerConfigId) > 0 {
+			ops = append(ops, plannedOperation{action: fmt.Sprintf("apply ranger config %s", rangerConfigId), impact: impactRangerApplied})
+		} else {
+			ops = append(ops, plannedOperation{action: "clear the ranger config", impact: impactRangerCleared})
+		}
+	}
+
+	if d.HasChange("custom_ami.0.ami") {
+		ops = append(ops, plannedOperation{
+			action: fmt.Sprintf("upgrade the AMI of all warehouses and coordinator nodes to %s", d.Get("custom_ami.0.ami").(string)),
+			impact: impactAllRestart,
+		})
+	}
+
+	if d.HasChange("expected_cluster_state") && d.Get("expected_cluster_state").(string) == string(cluster.ClusterStateSuspended) {
+		ops = append(ops, plannedOperation{action: "suspend the cluster", impact: impactClusterDowntime})
+	}
+
+	return ops
+}
+
+// planWarehouseOperations mirrors the order of the operations performed by updateWarehouse.
+func planWarehouseOperations(oldParamMap, newParamMap map[string]interface{}, isDefaultWarehouse, multiAz bool) []plannedOperation {
+	ops := make([]plannedOperation, 0)
+	whName := newParamMap["name"].(string)
+	if isDefaultWarehouse {
+		whName = DEFAULT_WAREHOUSE_NAME
+	}
+
+	expectedStateChanged := !isDefaultWarehouse && oldParamMap["expected_state"].(string) != newParamMap["expected_state"].(string)
+	if expectedStateChanged && newParamMap["expected_state"].(string) == string(cluster.ClusterStateRunning) {
+		ops = append(ops, plannedOperation{action: fmt.Sprintf("resume warehouse[%s]", whName), impact: impactOnline})
+	}
+
+	distributionChanged := oldParamMap["distribution_policy"].(string) != newParamMap["distribution_policy"].(string) ||
+		(newParamMap["distribution_policy"].(string) == string(cluster.DistributionPolicySpecifyAZ) && oldParamMap["specify_az"].(string) != newParamMap["specify_az"].(string))
+	if distributionChanged && multiAz {
+		ops = append(ops, plannedOperation{
+			action: fmt.Sprintf("change the distribution policy of warehouse[%s] to %s", whName, newParamMap["distribution_policy"].(string)),
+			impact: impactComputeNodeRecreation,
+		})
+	}
+
+	if oldParamMap["compute_node_size"].(string) != newParamMap["compute_node_size"].(string) {
+		ops = append(ops, plannedOperation{
+			action: fmt.Sprintf("resize compute nodes of warehouse[%s] from %s to %s", whName, oldParamMap["compute_node_size"].(string), newParamMap["compute_node_size"].(string)),
+			impact: impactComputeRestart,
+		})
+	}
+
+	oldCount, newCount := oldParamMap["compute_node_count"].(int), newParamMap["compute_node_count"].(int)
+	if newCount > oldCount {
+		ops = append(ops, plannedOperation{
+			action: fmt.Sprintf("scale out compute nodes of warehouse[%s] from %d to %d", whName, oldCount, newCount),
+			impact: impactOnline,
+		})
+	} else if newCount < oldCount {
+		ops = append(ops, plannedOperation{
+			action: fmt.Sprintf("scale in compute nodes of warehouse[%s] from %d to %d", whName, oldCount, newCount),
+			impact: impactComputeNodeRemoval,
+		})
+	}
+
+	oldVolumeConfig, newVolumeConfig := cluster.DefaultBeVolumeMap(), cluster.DefaultBeVolumeMap()
+	if len(oldParamMap["compute_node_volume_config"].([]interface{})) > 0 {
+		oldVolumeConfig = oldParamMap["compute_node_volume_config"].([]interface{})[0].(map[string]interface{})
+	}
+	if len(newParamMap["compute_node_volume_config"].([]interface{})) > 0 {
+		newVolumeConfig = newParamMap["compute_node_volume_config"].([]interface{})[0].(map[string]interface{})
+	}
+	if !cluster.Equal(oldVolumeConfig, newVolumeConfig) {
+		ops = append(ops, plannedOperation{action: fmt.Sprintf("modify the volumes of warehouse[%s]", whName), impact: impactOnline})
+	}
+
+	if !cluster.Equal(oldParamMap["compute_node_configs"], newParamMap["compute_node_configs"]) {
+		ops = append(ops, plannedOperation{action: fmt.Sprintf("apply compute node configs of warehouse[%s]", whName), impact: impactComputeRestart})
+	}
+
+	if expectedStateChanged && newParamMap["expected_state"].(string) == string(cluster.ClusterStateSuspended) {
+		ops = append(ops, plannedOperation{action: fmt.Sprintf("suspend warehouse[%s]", whName), impact: impactWarehouseDowntime})
+	}
+
+	return ops
+}
+
+func formatPlannedOperations(ops []plannedOperation) string {
+	summary := make([]string, 0, len(ops))
+	for i, op := range ops {
+		summary = append(summary, fmt.Sprintf("%d. %s", i+1, op))
+	}
+	return strings.Join(summary, "\n")
+}
+
+// setPlannedOperations plans the operations of the update into `planned_operations`. A plan without changes leaves
+// the attribute alone, so it doesn't cause a diff of its own.
+func setPlannedOperations(d *schema.ResourceDiff, multiAz bool) error {
+	if len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+
+	ops := planElasticClusterV2Operations(d, multiAz)
+	summary := make([]string, 0, len(ops))
+	for i, op := range ops {
+		summary = append(summary, fmt.Sprintf("%d. %s", i+1, op))
+	}
+	if o := d.Get("planned_operations").([]interface{}); len(o) == 0 && len(summary) == 0 {
+		return nil
+	}
+	log.Printf("[DEBUG] cluster (%s) update will perform the following operations:\n%s", d.Id(), strings.Join(summary, "\n"))
+	return d.SetNew("planned_operations", summary)
+}
+
+// performedOperationsWarning returns a warning listing the operations of the applied update if any of them is disruptive.
+func performedOperationsWarning(d *schema.ResourceData, multiAz bool) diag.Diagnostics {
+	ops := planElasticClusterV2Operations(d, multiAz)
+	for _, op := range ops {
+		if op.disruptive() {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Cluster (%s) update performed disruptive operations", d.Id()),
+					Detail:   formatPlannedOperations(ops),
+				},
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package celerdatabyoc
+
+import (
+	"reflect"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"testing"
+)
+
+type fakePlanDiff struct {
+	old map[string]interface{}
+	new map[string]interface{}
+}
+
+func (f *fakePlanDiff) Get(key string) interface{} {
+	return f.new[key]
+}
+
+func (f *fakePlanDiff) HasChange(key string) bool {
+	return !reflect.DeepEqual(f.old[key], f.new[key])
+}
+
+func (f *fakePlanDiff) GetChange(key string) (interface{}, interface{}) {
+	return f.old[key], f.new[key]
+}
+
+func testPlanWarehouse(name string, size string, count int, policy string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                       name,
+		"expected_state":             string(cluster.ClusterStateRunning),
+		"distribution_policy":        policy,
+		"specify_az":                 "",
+		"compute_node_size":          size,
+		"compute_node_count":         count,
+		"compute_node_volume_config": []interface{}{},
+		"compute_node_configs":       map[string]interface{}{},
+	}
+}
+
+func TestPlanElasticClusterV2Operations(t *testing.T) {
+	cases := []struct {
+		name    string
+		old     map[string]interface{}
+		new     map[string]interface{}
+		multiAz bool
+		want    []plannedOperation
+	}{
+		{
+			name: "no changes",
+			old:  map[string]interface{}{"coordinator_node_size": "m6i.xlarge"},
+			new:  map[string]interface{}{"coordinator_node_size": "m6i.xlarge"},
+			want: []plannedOperation{},
+		},
+		{
+			name: "coordinator resize in both directions",
+			old:  map[string]interface{}{"coordinator_node_size": "m6i.2xlarge", "coordinator_node_count": 3},
+			new:  map[string]interface{}{"coordinator_node_size": "m6i.xlarge", "coordinator_node_count": 1},
+			want: []plannedOperation{
+				{action: "resize coordinator nodes from m6i.2xlarge to m6i.xlarge", impact: impactCoordinatorRestart},
+				{action: "scale in coordinator nodes from 3 to 1", impact: impactCoordinatorNodeRemoval},
+			},
+		},
+		{
+			name: "resume first and suspend last",
+			old: map[string]interface{}{
+				"expected_cluster_state": string(cluster.ClusterStateSuspended),
+				"scheduling_policy":      []interface{}{},
+			},
+			new: map[string]interface{}{
+				"expected_cluster_state": string(cluster.ClusterStateRunning),
+				"scheduling_policy":      []interface{}{map[string]interface{}{"policy_name": "p1"}},
+			},
+			want: []plannedOperation{
+				{action: "update the scheduling policies", impact: impactOnline},
+				{action: "resume the cluster", impact: impactOnline},
+			},
+		},
+		{
+			name: "suspend",
+			old:  map[string]interface{}{"expected_cluster_state": string(cluster.ClusterStateRunning), "coordinator_node_count": 1},
+			new:  map[string]interface{}{"expected_cluster_state": string(cluster.ClusterStateSuspended), "coordinator_node_count": 3},
+			want: []plannedOperation{
+				{action: "scale out coordinator nodes from 1 to 3", impact: impactOnline},
+				{action: "suspend the cluster", impact: impactClusterDowntime},
+			},
+		},
+		{
+			name: "warehouses",
+			old: map[string]interface{}{
+				"warehouse": []interface{}{
+					testPlanWarehouse("wh1", "m6i.xlarge", 3, string(cluster.DistributionPolicySpecifyAZ)),
+					testPlanWarehouse("wh2", "m6i.xlarge", 1, ""),
+				},
+			},
+			new: map[string]interface{}{
+				"warehouse": []interface{}{
+					testPlanWarehouse("wh1", "m6i.2xlarge", 2, string(cluster.DistributionPolicyCrossingAZ)),
+					testPlanWarehouse("wh3", "m6i.xlarge", 1, ""),
+				},
+			},
+			multiAz: true,
+			want: []plannedOperation{
+				{action: "change the distribution policy of warehouse[wh1] to crossing_az", impact: impactComputeNodeRecreation},
+				{action: "resize compute nodes of warehouse[wh1] from m6i.xlarge to m6i.2xlarge", impact: impactComputeRestart},
+				{action: "scale in compute nodes of warehouse[wh1] from 3 to 2", impact: impactComputeNodeRemoval},
+				{action: "create warehouse[wh3]", impact: impactOnline},
+				{action: "release warehouse[wh2]", impact: impactWarehouseRelease},
+			},
+		},
+		{
+			name: "distribution policy ignored without multi-az",
+			old: map[string]interface{}{
+				"default_warehouse": []interface{}{testPlanWarehouse("", "m6i.xlarge", 1, string(cluster.DistributionPolicySpecifyAZ))},
+			},
+			new: map[string]interface{}{
+				"default_warehouse": []interface{}{testPlanWarehouse("", "m6i.xlarge", 2, string(cluster.DistributionPolicyCrossingAZ))},
+			},
+			want: []plannedOperation{
+				{action: "scale out compute nodes of warehouse[default_warehouse] from 1 to 2", impact: impactOnline},
+			},
+		},
+		{
+			name: "scripts, ranger and ami",
+			old: map[string]interface{}{
+				"scripts":          []interface{}{},
+				"ranger_config_id": "rc1",
+				"custom_ami.0.ami": "ami-1",
+			},
+			new: map[string]interface{}{
+				"scripts":          []interface{}{map[string]interface{}{"script_path": "s3://bucket/a.sh"}},
+				"ranger_config_id": "",
+				"custom_ami.0.ami": "ami-2",
+			},
+			want: []plannedOperation{
+				{action: "run the added, changed and rerun scripts", impact: impactScriptsRun},
+				{action: "clear the ranger config", impact: impactRangerCleared},
+				{action: "upgrade the AMI of all warehouses and coordinator nodes to ami-2", impact: impactAllRestart},
+			},
+		},
+		{
+			name: "arrow flight and ranger applied",
+			old:  map[string]interface{}{"enabled_arrow_flight": false, "ranger_config_id": ""},
+			new:  map[string]interface{}{"enabled_arrow_flight": true, "ranger_config_id": "rc2"},
+			want: []plannedOperation{
+				{action: "enable Arrow Flight", impact: impactCoordinatorRestart},
+				{action: "apply ranger config rc2", impact: impactRangerApplied},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := planElasticClusterV2Operations(&fakePlanDiff{old: tc.old, new: tc.new}, tc.multiAz)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("planElasticClusterV2Operations() =\n%s\nwant\n%s", formatPlannedOperations(got), formatPlannedOperations(tc.want))
+			}
+		})
+	}
+}
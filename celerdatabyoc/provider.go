@@ -89,11 +89,37 @@ func Provider() *schema.Provider {
 			"celerdatabyoc_gcp_deployment_credential":               gcpResourceDeploymentCredential(),
 			"celerdatabyoc_gcp_network":                             gcpResourceNetwork(),
 			"celerdatabyoc_ranger_config":                           resourceRangerConfig(),
+			"celerdatabyoc_warehouse":                               resourceWarehouse(),
+			"celerdatabyoc_cluster_scheduling_policy":               resourceClusterSchedulingPolicy(),
+			"celerdatabyoc_cluster_node_config":                     resourceClusterNodeConfig(),
+			"celerdatabyoc_cluster_global_variables":                resourceClusterGlobalVariables(),
+			"celerdatabyoc_cluster_action":                          resourceClusterAction(),
+			"celerdatabyoc_cluster_ldap":                            resourceClusterLdap(),
+			"celerdatabyoc_cluster_ranger_binding":                  resourceClusterRangerBinding(),
+			"celerdatabyoc_cluster_settings":                        resourceClusterSettings(),
+			"celerdatabyoc_cluster_script":                          resourceClusterScript(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"celerdatabyoc_aws_data_credential_assume_policy": dataAwsDataCredentialAssumeRolePolicy(),
-			"celerdatabyoc_data_cluster_volume_detail":        dataSourceClusterVolumeDetail(),
-			"celerdatabyoc_clusters":                          dataSourceClusters(),
+			"celerdatabyoc_aws_data_credential_assume_policy":       dataAwsDataCredentialAssumeRolePolicy(),
+			"celerdatabyoc_aws_data_credential_policy":              dataAwsDataCredentialPolicy(),
+			"celerdatabyoc_aws_deployment_credential_policy":        dataAwsDeploymentCredentialPolicy(),
+			"celerdatabyoc_aws_deployment_credential_assume_policy": dataAwsDeploymentCredentialAssumePolicy(),
+			"celerdatabyoc_data_cluster_volume_detail":              dataSourceClusterVolumeDetail(),
+			"celerdatabyoc_clusters":                                dataSourceClusters(),
+			"celerdatabyoc_cluster":                                 dataSourceCluster(),
+			"celerdatabyoc_warehouse":                               dataSourceWarehouse(),
+			"celerdatabyoc_warehouses":                              dataSourceWarehouses(),
+			"celerdatabyoc_warehouse_auto_scaling_policy":           dataSourceWarehouseAutoScalingPolicy(),
+			"celerdatabyoc_cluster_endpoints":                       dataSourceClusterEndpoints(),
+			"celerdatabyoc_cluster_versions":                        dataSourceClusterVersions(),
+			"celerdatabyoc_vm_instance_types":                       dataSourceVmInstanceTypes(),
+			"celerdatabyoc_network":                                 dataSourceNetwork(),
+			"celerdatabyoc_data_credential":                         dataSourceDataCredential(),
+			"celerdatabyoc_deployment_credential":                   dataSourceDeploymentCredential(),
+			"celerdatabyoc_azure_deployment_credential_role":        dataSourceAzureDeploymentCredentialRole(),
+			"celerdatabyoc_azure_data_credential_role":              dataSourceAzureDataCredentialRole(),
+			"celerdatabyoc_gcp_deployment_credential_role":          dataSourceGcpDeploymentCredentialRole(),
+			"celerdatabyoc_gcp_data_credential_role":                dataSourceGcpDataCredentialRole(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
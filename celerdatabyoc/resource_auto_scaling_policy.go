@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
@@ -304,3 +305,83 @@ func ValidateAutoScalingPolicy(autoScalingConfig *cluster.WarehouseAutoScalingCo
 	}
 	return nil
 }
+
+// FlattenAutoScalingPolicyItems decodes the policy items of a warehouse auto-scaling config back into the
+// `policy_item` schema of celerdatabyoc_auto_scaling_policy.
+func FlattenAutoScalingPolicyItems(autoScalingConfig *cluster.WarehouseAutoScalingConfig) []interface{} {
+	policyItems := make([]interface{}, 0, len(autoScalingConfig.PolicyItem))
+	for _, item := range autoScalingConfig.PolicyItem {
+		conditions := make([]interface{}, 0, len(item.Conditions))
+		for _, cond := range item.Conditions {
+			value, _ := strconv.ParseFloat(cond.Value, 64)
+			conditions = append(conditions, map[string]interface{}{
+				"type":             cluster.MetricToStr[cond.Type],
+				"duration_seconds": cond.DurationSeconds,
+				"value":            value,
+			})
+		}
+		policyItems = append(policyItems, map[string]interface{}{
+			"type":      cluster.ScaleTypeToStr[item.Type],
+			"step_size": item.StepSize,
+			"condition": conditions,
+		})
+	}
+	return policyItems
+}
+
+func autoScalingUnitToDisplay(unit cluster.AutoScalingUnit) string {
+	for k, v := range cluster.AutoScalingUnitDisplayToType {
+		if v == unit {
+			return string(k)
+		}
+	}
+	return string(cluster.AutoScalingUnitDisplay_SINGLE)
+}
+
+// NormalizeAutoScalingPolicy sorts the policy items and their conditions and formats the condition values
+// the way ToAutoScalingConfigStruct does, so that equivalent policies are serialized to the same JSON.
+func NormalizeAutoScalingPolicy(autoScalingConfig *cluster.WarehouseAutoScalingConfig) {
+	for _, item := range autoScalingConfig.PolicyItem {
+		for _, cond := range item.Conditions {
+			if value, err := strconv.ParseFloat(cond.Value, 64); err == nil {
+				cond.Value = fmt.Sprintf("%.2f", math.Round(value*100)/100)
+			}
+		}
+		sort.SliceStable(item.Conditions, func(i, j int) bool {
+			return item.Conditions[i].Type < item.Conditions[j].Type
+		})
+	}
+	sort.SliceStable(autoScalingConfig.PolicyItem, func(i, j int) bool {
+		a, b := autoScalingConfig.PolicyItem[i], autoScalingConfig.PolicyItem[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.StepSize < b.StepSize
+	})
+}
+
+// autoScalingPolicyJson returns the normalized JSON of the policy, or an empty string if auto scaling is disabled.
+func autoScalingPolicyJson(autoScalingConfig *cluster.WarehouseAutoScalingConfig) string {
+	if autoScalingConfig == nil || !autoScalingConfig.State {
+		return ""
+	}
+	NormalizeAutoScalingPolicy(autoScalingConfig)
+	bytes, _ := json.Marshal(autoScalingConfig)
+	return string(bytes)
+}
+
+// suppressEquivalentAutoScalingPolicy hides the diffs of `auto_scaling_policy` that only come from the order of
+// the policy items or the formatting of the JSON, the policies read from the server are still compared by value.
+func suppressEquivalentAutoScalingPolicy(k, old, new string, d *schema.ResourceData) bool {
+	if len(old) == 0 || len(new) == 0 {
+		return old == new
+	}
+	oldConfig := &cluster.WarehouseAutoScalingConfig{}
+	newConfig := &cluster.WarehouseAutoScalingConfig{}
+	if json.Unmarshal([]byte(old), oldConfig) != nil || json.Unmarshal([]byte(new), newConfig) != nil {
+		return false
+	}
+	// The policy is saved with its state enabled.
+	oldConfig.State, newConfig.State = true, true
+	return autoScalingPolicyJson(oldConfig) == autoScalingPolicyJson(newConfig)
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/credential"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/csp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -85,8 +86,6 @@ func resourceDataCredentialRead(ctx context.Context, d *schema.ResourceData, m i
 	// Warning or errors can be collected in a slice type
 	credID := d.Id()
 	credCli := credential.NewCredentialAPI(c)
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
 
 	log.Printf("[DEBUG] get data credential, id[%s]", credID)
 	resp, err := credCli.GetDataCredential(ctx, credID)
@@ -96,7 +95,9 @@ func resourceDataCredentialRead(ctx context.Context, d *schema.ResourceData, m i
 
 	log.Printf("[DEBUG] get data credential, resp:%+v", resp)
 
-	return diags
+	return checkAwsPolicyVersion(ctx, c, "data", credID, resp.DataCred.PolicyVersion, func(awsCsp *csp.CSP) string {
+		return awsCsp.DataCredPolicyVersion
+	})
 }
 
 func resourceDataCredentialDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
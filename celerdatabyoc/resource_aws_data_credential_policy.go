@@ -2,9 +2,7 @@ package celerdatabyoc
 
 import (
 	"context"
-	"strings"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
-	"terraform-provider-celerdatabyoc/celerdata-sdk/service/csp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,6 +13,8 @@ func resourceAwsDataCredentialPolicy() *schema.Resource {
 		CreateContext: resourceAwsDataCredentialPolicyCreate,
 		ReadContext:   resourceAwsDataCredentialPolicyRead,
 		DeleteContext: resourceAwsDataCredentialPolicyDelete,
+		DeprecationMessage: "This resource is deprecated and doesn't pick up new versions of the policy template. " +
+			"Please use the `celerdatabyoc_aws_data_credential_policy` data source",
 		Schema: map[string]*schema.Schema{
 			"bucket": {
 				Type:     schema.TypeString,
@@ -39,20 +39,15 @@ func resourceAwsDataCredentialPolicyCreate(ctx context.Context, d *schema.Resour
 
 func resourceAwsDataCredentialPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	bucket := d.Get("bucket").(string)
-	c := m.(*client.CelerdataClient)
-	cspAPI := csp.NewCspAPI(c)
-	resp, err := cspAPI.Get(ctx, &csp.GetReq{
-		CspName: "aws",
-	})
+	policyJSON, version, err := renderAwsDataCredentialPolicy(ctx, m.(*client.CelerdataClient), bucket)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	policyJSON := strings.Replace(resp.Csp.DataCredPolicy, "<s3-bucket-name>", bucket, -1)
 	d.SetId("data_credential")
 	// nolint
-	d.Set("json", string(policyJSON))
-	d.Set("version", resp.Csp.DataCredPolicyVersion)
+	d.Set("json", policyJSON)
+	d.Set("version", version)
 	return diags
 }
 
@@ -2,10 +2,7 @@ package celerdatabyoc
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
-	"terraform-provider-celerdatabyoc/celerdata-sdk/service/csp"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -34,6 +31,8 @@ func resourceAwsDeployCredAssumePolicy() *schema.Resource {
 		CreateContext: resourceAwsDeployCredAssumePolicyCreate,
 		ReadContext:   resourceAwsDeployCredAssumePolicyRead,
 		DeleteContext: resourceAwsDeployCredAssumePolicyDelete,
+		DeprecationMessage: "This resource is deprecated. Please use the `celerdatabyoc_aws_deployment_credential_assume_policy` data source " +
+			"with an external ID from a `random_uuid` resource",
 		Schema: map[string]*schema.Schema{
 			"external_id": {
 				Type:     schema.TypeString,
@@ -50,42 +49,15 @@ func resourceAwsDeployCredAssumePolicy() *schema.Resource {
 }
 
 func resourceAwsDeployCredAssumePolicyCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*client.CelerdataClient)
-	cspAPI := csp.NewCspAPI(c)
-
-	resp, err := cspAPI.Get(ctx, &csp.GetReq{
-		CspName: "aws",
-	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
 	externalID := uuid.NewString()
-	policy := awsIamPolicy{
-		Version: "2012-10-17",
-		Statements: []*awsIamPolicyStatement{
-			{
-				Effect:  "Allow",
-				Actions: "sts:AssumeRole",
-				Condition: map[string]map[string]string{
-					"StringEquals": {
-						"sts:ExternalId": externalID,
-					},
-				},
-				Principal: map[string]string{
-					"AWS": fmt.Sprintf("arn:aws:iam::%s:root", resp.Csp.TrustAccountId),
-				},
-			},
-		},
-	}
-	policyJSON, err := json.MarshalIndent(policy, "", "  ")
+	policyJSON, err := renderAwsDeploymentCredentialAssumePolicy(ctx, m.(*client.CelerdataClient), externalID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(externalID)
 	// nolint
-	d.Set("json", string(policyJSON))
+	d.Set("json", policyJSON)
 	d.Set("external_id", externalID)
 	return nil
 }
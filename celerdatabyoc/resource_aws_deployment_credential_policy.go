@@ -2,9 +2,7 @@ package celerdatabyoc
 
 import (
 	"context"
-	"strings"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
-	"terraform-provider-celerdatabyoc/celerdata-sdk/service/csp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,6 +13,8 @@ func resourceAwsDeploymentCredentialPolicy() *schema.Resource {
 		CreateContext: resourceAwsDeploymentCredentialPolicyCreate,
 		ReadContext:   resourceAwsDeploymentCredentialPolicyRead,
 		DeleteContext: resourceAwsDeploymentCredentialPolicyDelete,
+		DeprecationMessage: "This resource is deprecated and doesn't pick up new versions of the policy template. " +
+			"Please use the `celerdatabyoc_aws_deployment_credential_policy` data source",
 		Schema: map[string]*schema.Schema{
 			"data_role_arn": {
 				Type:     schema.TypeString,
@@ -45,20 +45,14 @@ func resourceAwsDeploymentCredentialPolicyCreate(ctx context.Context, d *schema.
 func resourceAwsDeploymentCredentialPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	dataRoleARN := d.Get("data_role_arn").(string)
 	bucket := d.Get("bucket").(string)
-	c := m.(*client.CelerdataClient)
-	cspAPI := csp.NewCspAPI(c)
-	resp, err := cspAPI.Get(ctx, &csp.GetReq{
-		CspName: "aws",
-	})
+	policyJSON, version, err := renderAwsDeploymentCredentialPolicy(ctx, m.(*client.CelerdataClient), dataRoleARN, bucket)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	policy := strings.Replace(resp.Csp.DeployCredPolicy, "<Storage Role ARN>", dataRoleARN, -1)
-	policyJSON := strings.Replace(policy, "<s3-bucket-name>", bucket, -1)
 	d.SetId("deployment_credential")
 	// nolint
-	d.Set("json", string(policyJSON))
-	d.Set("version", resp.Csp.DeployCredPolicyVersion)
+	d.Set("json", policyJSON)
+	d.Set("version", version)
 
 	return diags
 }
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/credential"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/csp"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -151,7 +152,12 @@ func resourceDeploymentRoleCredentialRead(ctx context.Context, d *schema.Resourc
 
 	log.Printf("[DEBUG] get deployment role credential, resp:%+v", resp)
 
-	return diags
+	if resp.DeployRoleCred == nil {
+		return diags
+	}
+	return checkAwsPolicyVersion(ctx, c, "deployment", credID, resp.DeployRoleCred.PolicyVersion, func(awsCsp *csp.CSP) string {
+		return awsCsp.DeployCredPolicyVersion
+	})
 }
 
 func resourceDeploymentRoleCredentialDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -44,9 +44,11 @@ func azureResourceDeploymentCredential() *schema.Resource {
 			"client_secret_value": {
 				Type:        schema.TypeString,
 				Description: "The client secret value of the App registration. Client secret values cannot be viewed, except for immediately after creation. Be sure to save the secret when created before leaving the Azure console.",
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 			},
+			"client_secret_value_wo":         writeOnlySchema("client_secret_value", "The client secret value of the App registration.", validation.ToDiagFunc(validation.StringIsNotEmpty)),
+			"client_secret_value_wo_version": writeOnlyVersionSchema("client_secret_value"),
 			"ssh_key_resource_id": {
 				Type:        schema.TypeString,
 				Description: "The resource ID of the SSH key pair.",
@@ -54,6 +56,7 @@ func azureResourceDeploymentCredential() *schema.Resource {
 				Required:    true,
 			},
 		},
+		ValidateRawResourceConfigFuncs: preferWriteOnly("client_secret_value"),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -69,7 +72,7 @@ func azureResourceDeploymentCredentialCreate(ctx context.Context, d *schema.Reso
 		Name:              d.Get("name").(string),
 		TenantId:          d.Get("directory_id").(string),
 		ApplicationId:     d.Get("application_id").(string),
-		ClientSecretValue: d.Get("client_secret_value").(string),
+		ClientSecretValue: getSecret(d, "client_secret_value"),
 		SshKeyResourceId:  d.Get("ssh_key_resource_id").(string),
 	}
 	log.Printf("[DEBUG] create deployment credential, req:%+v", req)
@@ -87,7 +90,7 @@ func azureResourceDeploymentCredentialUpdate(ctx context.Context, d *schema.Reso
 
 	var diags diag.Diagnostics
 
-	if !d.IsNewResource() && d.HasChange("client_secret_value") {
+	if !d.IsNewResource() && secretHasChange(d, "client_secret_value") {
 		log.Printf("[DEBUG] rotate deployment credential")
 		c := m.(*client.CelerdataClient)
 
@@ -97,7 +100,7 @@ func azureResourceDeploymentCredentialUpdate(ctx context.Context, d *schema.Reso
 		err := credCli.RotateAkSkCredential(ctx, &credential.RotateAkSkCredentialReq{
 			CredID: credID,
 			Ak:     d.Get("application_id").(string),
-			Sk:     d.Get("client_secret_value").(string),
+			Sk:     getSecret(d, "client_secret_value"),
 		})
 		if err != nil {
 			return diag.FromErr(err)
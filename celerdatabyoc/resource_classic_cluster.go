@@ -16,6 +16,7 @@ import (
 	"terraform-provider-celerdatabyoc/common"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -159,11 +160,11 @@ func resourceClassicCluster() *schema.Resource {
 			},
 			"default_admin_password": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
 				Sensitive:        true,
 				ValidateDiagFunc: common.ValidatePassword(),
-				ForceNew:         true,
 			},
+			"default_admin_password_wo": writeOnlySchema("default_admin_password", "The initial password of the cluster `admin` user.", common.ValidatePassword()),
 			"data_credential_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -392,27 +393,49 @@ func resourceClassicCluster() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"ignore_unmanaged_scheduling_policies": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to ignore the scheduling policies that are not declared in `scheduling_policy` blocks, such as the ones managed by `celerdatabyoc_cluster_scheduling_policy` resources. When it's false, such policies are deleted.",
+			},
 			"ranger_config_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 		},
+		ValidateRawResourceConfigFuncs: preferWriteOnly("default_admin_password"),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
 			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
 		},
 		CustomizeDiff: classicCustomizeElDiff,
 	}
 }
 
 func classicCustomizeElDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Changing the password recreates the cluster, while moving it to `default_admin_password_wo` only removes it from the state.
+	replaceKeys := classicClusterForceNewKeys
+	if o, n := d.GetChange("default_admin_password"); len(o.(string)) > 0 && len(n.(string)) > 0 && o.(string) != n.(string) {
+		if err := d.ForceNew("default_admin_password"); err != nil {
+			return err
+		}
+		replaceKeys = append([]string{"default_admin_password"}, replaceKeys...)
+	}
+
+	if err := checkTerminationProtectionOnReplace(d, replaceKeys); err != nil {
+		return err
+	}
+
 	c := m.(*client.CelerdataClient)
-	clusterAPI := cluster.NewClustersAPI(c)
 	networkAPI := network.NewNetworkAPI(c)
+	vmCatalog := cluster.SharedVmCatalog(c)
 
 	csp := d.Get("csp").(string)
 	region := d.Get("region").(string)
@@ -423,18 +446,9 @@ func classicCustomizeElDiff(ctx context.Context, d *schema.ResourceDiff, m inter
 		_, n := d.GetChange("fe_instance_type")
 		feInstanceType = n.(string)
 	}
-	feVmInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-		Csp:         csp,
-		Region:      region,
-		ProcessType: string(cluster.ClusterModuleTypeFE),
-		VmCate:      feInstanceType,
-	})
+	feVmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeFE), feInstanceType, cty.GetAttrPath("fe_instance_type"))
 	if err != nil {
-		log.Printf("[ERROR] query instance type failed, csp:%s region:%s instance type:%s err:%+v", csp, region, feInstanceType, err)
-		return fmt.Errorf("query instance type failed, csp:%s region:%s instance type:%s errMsg:%s", csp, region, feInstanceType, err.Error())
-	}
-	if feVmInfoResp.VmInfo == nil {
-		return fmt.Errorf("instance type not exists, csp:%s region:%s instance type:%s", csp, region, feInstanceType)
+		return err
 	}
 
 	if d.HasChange("fe_volume_config") && !isNewResource {
@@ -453,22 +467,20 @@ func classicCustomizeElDiff(ctx context.Context, d *schema.ResourceDiff, m inter
 		oldVolumeSize, newVolumeSize := oldVolumeConfig["vol_size"].(int), newVolumeConfig["vol_size"].(int)
 
 		if newVolumeSize < oldVolumeSize {
-			return fmt.Errorf("the fe `vol_size` does not support decrease")
+			return cty.GetAttrPath("fe_volume_config").IndexInt(0).GetAttr("vol_size").NewErrorf("the fe `vol_size` does not support decrease")
 		}
 	}
-	if !feVmInfoResp.VmInfo.IsInstanceStore {
+	if !feVmInfo.IsInstanceStore {
 		if v, ok := d.GetOk("fe_volume_config"); ok {
-			nodeType := cluster.ClusterModuleTypeFE
-			volumeCate := feVmInfoResp.VmInfo.VmVolumeInfos[0].VolumeCate
-			volumeConfig := v.([]interface{})[0].(map[string]interface{})
 			err = VolumeParamVerify(ctx, &VolumeParamVerifyReq{
-				ClusterAPI:   clusterAPI,
-				VolumeCate:   volumeCate,
-				VolumeConfig: volumeConfig,
+				VmCatalog:    vmCatalog,
+				NodeType:     string(cluster.ClusterModuleTypeFE),
+				VolumeCate:   feVmInfo.VmVolumeInfos[0].VolumeCate,
+				VolumeConfig: v.([]interface{})[0].(map[string]interface{}),
+				Path:         cty.GetAttrPath("fe_volume_config").IndexInt(0),
 			})
 			if err != nil {
-				log.Printf("[ERROR] verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-				return fmt.Errorf("verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
+				return err
 			}
 		}
 	}
@@ -478,18 +490,9 @@ func classicCustomizeElDiff(ctx context.Context, d *schema.ResourceDiff, m inter
 		_, n := d.GetChange("be_instance_type")
 		beInstanceType = n.(string)
 	}
-	beVmInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-		Csp:         csp,
-		Region:      region,
-		ProcessType: string(cluster.ClusterModuleTypeBE),
-		VmCate:      beInstanceType,
-	})
+	beVmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeBE), beInstanceType, cty.GetAttrPath("be_instance_type"))
 	if err != nil {
-		log.Printf("[ERROR] query instance type failed, csp:%s region:%s instance type:%s err:%+v", csp, region, beInstanceType, err)
-		return fmt.Errorf("query instance type failed, csp:%s region:%s instance type:%s errMsg:%s", csp, region, beInstanceType, err.Error())
-	}
-	if beVmInfoResp.VmInfo == nil {
-		return fmt.Errorf("instance type not exists, csp:%s region:%s instance type:%s", csp, region, beInstanceType)
+		return err
 	}
 
 	if d.HasChange("be_volume_config") && !isNewResource {
@@ -512,36 +515,35 @@ func classicCustomizeElDiff(ctx context.Context, d *schema.ResourceDiff, m inter
 		oldVolumeNum, oldVolumeSize = oldVolumeConfig["vol_number"].(int), oldVolumeConfig["vol_size"].(int)
 		newVolumeNum, newVolumeSize = newVolumeConfig["vol_number"].(int), newVolumeConfig["vol_size"].(int)
 
+		volumePath := cty.GetAttrPath("be_volume_config").IndexInt(0)
 		if newVolumeNum < oldVolumeNum {
-			return fmt.Errorf("the be `vol_number` is not allowed to be decreased for classic clusters")
+			return volumePath.GetAttr("vol_number").NewErrorf("the be `vol_number` is not allowed to be decreased for classic clusters")
 		}
 
-		if newVolumeNum > int(beVmInfoResp.VmInfo.MaxDataDiskCount) {
-			return fmt.Errorf("the maximum allowed `vol_number` for this VM type is: %d", beVmInfoResp.VmInfo.MaxDataDiskCount)
+		if newVolumeNum > int(beVmInfo.MaxDataDiskCount) {
+			return volumePath.GetAttr("vol_number").NewErrorf("the maximum allowed `vol_number` for this VM type is: %d", beVmInfo.MaxDataDiskCount)
 		}
 
 		if newVolumeNum < 1 {
-			return fmt.Errorf("the minimum allowed `vol_number` is: 1")
+			return volumePath.GetAttr("vol_number").NewErrorf("the minimum allowed `vol_number` is: 1")
 		}
 
 		if newVolumeSize < oldVolumeSize {
-			return fmt.Errorf("the be `vol_size` does not support decrease")
+			return volumePath.GetAttr("vol_size").NewErrorf("the be `vol_size` does not support decrease")
 		}
 	}
 
-	if !beVmInfoResp.VmInfo.IsInstanceStore {
+	if !beVmInfo.IsInstanceStore {
 		if v, ok := d.GetOk("be_volume_config"); ok {
-			nodeType := cluster.ClusterModuleTypeBE
-			volumeCate := feVmInfoResp.VmInfo.VmVolumeInfos[0].VolumeCate
-			volumeConfig := v.([]interface{})[0].(map[string]interface{})
 			err = VolumeParamVerify(ctx, &VolumeParamVerifyReq{
-				ClusterAPI:   clusterAPI,
-				VolumeCate:   volumeCate,
-				VolumeConfig: volumeConfig,
+				VmCatalog:    vmCatalog,
+				NodeType:     string(cluster.ClusterModuleTypeBE),
+				VolumeCate:   beVmInfo.VmVolumeInfos[0].VolumeCate,
+				VolumeConfig: v.([]interface{})[0].(map[string]interface{}),
+				Path:         cty.GetAttrPath("be_volume_config").IndexInt(0),
 			})
 			if err != nil {
-				log.Printf("[ERROR] verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-				return fmt.Errorf("verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
+				return err
 			}
 		}
 	}
@@ -608,12 +610,8 @@ func SchedulingPolicyParamCheck(d *schema.ResourceDiff) error {
 				return fmt.Errorf("Duplicate scheduling policy name `%s`", m["policy_name"].(string))
 			}
 
-			if m["resume_at"].(string) == "" && m["suspend_at"].(string) == "" {
-				return fmt.Errorf("For scheduling policy [`%s`], field `resume_at` and `suspend_at` cannot be empty at the same time.", m["policy_name"].(string))
-			}
-
-			if m["resume_at"].(string) == m["suspend_at"].(string) {
-				return fmt.Errorf("For scheduling policy [`%s`], field `resume_at` and `suspend_at` cannot be the same", m["policy_name"].(string))
+			if err := checkSchedulingPolicyTime(m["policy_name"].(string), m["resume_at"].(string), m["suspend_at"].(string)); err != nil {
+				return err
 			}
 
 			policyNameMap[m["policy_name"].(string)] = true
@@ -622,6 +620,17 @@ func SchedulingPolicyParamCheck(d *schema.ResourceDiff) error {
 	return nil
 }
 
+func checkSchedulingPolicyTime(policyName, resumeAt, suspendAt string) error {
+	if resumeAt == "" && suspendAt == "" {
+		return fmt.Errorf("For scheduling policy [`%s`], field `resume_at` and `suspend_at` cannot be empty at the same time.", policyName)
+	}
+
+	if resumeAt == suspendAt {
+		return fmt.Errorf("For scheduling policy [`%s`], field `resume_at` and `suspend_at` cannot be the same", policyName)
+	}
+	return nil
+}
+
 func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
 	c := m.(*client.CelerdataClient)
 
@@ -634,7 +643,7 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, m interf
 		Csp:                          d.Get("csp").(string),
 		Region:                       d.Get("region").(string),
 		ClusterType:                  cluster.ClusterTypeClassic,
-		Password:                     d.Get("default_admin_password").(string),
+		Password:                     getSecret(d, "default_admin_password"),
 		SslConnEnable:                true,
 		NetIfaceId:                   d.Get("network_id").(string),
 		DeployCredlId:                d.Get("deployment_credential_id").(string),
@@ -744,7 +753,7 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, m interf
 		clusterAPI: clusterAPI,
 		clusterID:  resp.ClusterID,
 		actionID:   resp.ActionID,
-		timeout:    common.DeployOrScaleClusterTimeout,
+		timeout:    d.Timeout(schema.TimeoutCreate),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -865,7 +874,7 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, m interf
 		policies := v.([]interface{})
 		for _, item := range policies {
 			m := item.(map[string]interface{})
-			err := SaveClusterSchedulingPolicy(ctx, clusterAPI, clusterId, m)
+			_, err := SaveClusterSchedulingPolicy(ctx, clusterAPI, clusterId, m)
 			if err != nil {
 				return diag.Diagnostics{
 					diag.Diagnostic{
@@ -904,7 +913,7 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, m interfac
 	stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterID,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutRead),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -949,6 +958,7 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, m interfac
 		log.Printf("[ERROR] query cluster fe config failed, err:%+v", err)
 		return diag.FromErr(err)
 	}
+	feConfigsResp.Configs = withoutLdapConfigs(feConfigsResp.Configs, d.Get("fe_configs"))
 
 	beConfigsResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
 		ClusterID:  clusterID,
@@ -978,6 +988,7 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, m interfac
 		log.Printf("[ERROR] list cluster schedule policy failed,clusterId:%s err:%+v", clusterID, err)
 		return diag.FromErr(err)
 	}
+	policies, policyExtraInfo = filterSchedulingPolicies(d, policies, policyExtraInfo)
 
 	terminationProtection, err := clusterAPI.GetClusterTerminationProtection(ctx, &cluster.GetClusterTerminationProtectionReq{ClusterId: clusterID})
 	if err != nil {
@@ -1033,7 +1044,8 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, m interfac
 		d.Set("ranger_certs_dir", resp.Cluster.RangerCertsDirPath)
 	}
 
-	if len(feConfigsResp.Configs) > 0 {
+	// The LDAP configs may have been dropped, so the configs are also set when the state has some.
+	if len(feConfigsResp.Configs) > 0 || len(d.Get("fe_configs").(map[string]interface{})) > 0 {
 		d.Set("fe_configs", feConfigsResp.Configs)
 	}
 
@@ -1088,10 +1100,14 @@ func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, m interf
 	log.Printf("[DEBUG] resourceClusterDelete cluster id:%s", clusterID)
 	var diags diag.Diagnostics
 
+	if diags := checkTerminationProtection(ctx, clusterAPI, clusterID); diags.HasError() {
+		return diags
+	}
+
 	_, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterID,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutDelete),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -1122,7 +1138,7 @@ func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, m interf
 		clusterAPI: clusterAPI,
 		actionID:   resp.ActionID,
 		clusterID:  clusterID,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutDelete),
 		pendingStates: []string{
 			string(cluster.ClusterStateReleasing),
 			string(cluster.ClusterStateRunning),
@@ -1178,7 +1194,7 @@ func needSuspend(d *schema.ResourceData) bool {
 func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var immutableFields = []string{"csp", "region", "cluster_name", "default_admin_password", "data_credential_id", "deployment_credential_id", "network_id", "query_port"}
 	for _, f := range immutableFields {
-		if d.HasChange(f) && !d.IsNewResource() {
+		if d.HasChange(f) && !d.IsNewResource() && !movedToWriteOnly(d, f) {
 			return diag.FromErr(fmt.Errorf("the `%s` field is not allowed to be modified", f))
 		}
 	}
@@ -1192,7 +1208,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterID,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutUpdate),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -1360,7 +1376,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			clusterAPI:    clusterAPI,
 			actionID:      resp.ActionId,
 			clusterID:     clusterID,
-			timeout:       common.DeployOrScaleClusterTimeout,
+			timeout:       d.Timeout(schema.TimeoutUpdate),
 			pendingStates: []string{string(cluster.ClusterStateScaling)},
 			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 		})
@@ -1406,7 +1422,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			clusterAPI:    clusterAPI,
 			actionID:      actionID,
 			clusterID:     clusterID,
-			timeout:       common.DeployOrScaleClusterTimeout,
+			timeout:       d.Timeout(schema.TimeoutUpdate),
 			pendingStates: []string{string(cluster.ClusterStateScaling)},
 			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 		})
@@ -1461,7 +1477,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 				clusterAPI: clusterAPI,
 				clusterID:  clusterID,
 				actionID:   infraActionId,
-				timeout:    30 * time.Minute,
+				timeout:    d.Timeout(schema.TimeoutUpdate),
 				pendingStates: []string{
 					string(cluster.ClusterInfraActionStatePending),
 					string(cluster.ClusterInfraActionStateOngoing),
@@ -1497,11 +1513,16 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if d.HasChange("fe_configs") {
-		configMap := d.Get("fe_configs").(map[string]interface{})
+		o, n := d.GetChange("fe_configs")
 		configs := make(map[string]string, 0)
-		for k, v := range configMap {
+		for k, v := range n.(map[string]interface{}) {
 			configs[k] = v.(string)
 		}
+		// The coordinator node configs are replaced as a whole, keep the ones of celerdatabyoc_cluster_ldap.
+		configs, err := mergeClusterLdapConfigs(ctx, clusterAPI, clusterID, configs, o.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		warnDiag := UpsertClusterConfig(ctx, clusterAPI, &cluster.UpsertClusterConfigReq{
 			ClusterID:  clusterID,
 			ConfigType: cluster.CustomConfigTypeFE,
@@ -1530,7 +1551,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			clusterAPI:    clusterAPI,
 			actionID:      resp.ActionId,
 			clusterID:     clusterID,
-			timeout:       common.DeployOrScaleClusterTimeout,
+			timeout:       d.Timeout(schema.TimeoutUpdate),
 			pendingStates: []string{string(cluster.ClusterStateScaling)},
 			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 		})
@@ -1576,7 +1597,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			clusterAPI:    clusterAPI,
 			actionID:      actionID,
 			clusterID:     clusterID,
-			timeout:       common.DeployOrScaleClusterTimeout,
+			timeout:       d.Timeout(schema.TimeoutUpdate),
 			pendingStates: []string{string(cluster.ClusterStateScaling)},
 			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 		})
@@ -1632,7 +1653,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 				clusterAPI: clusterAPI,
 				clusterID:  clusterID,
 				actionID:   infraActionId,
-				timeout:    30 * time.Minute,
+				timeout:    d.Timeout(schema.TimeoutUpdate),
 				pendingStates: []string{
 					string(cluster.ClusterInfraActionStatePending),
 					string(cluster.ClusterInfraActionStateOngoing),
@@ -1825,7 +1846,7 @@ func HandleChangedClusterSchedulingPolicy(ctx context.Context, api cluster.IClus
 	}
 
 	for _, item := range newPolicies {
-		err := SaveClusterSchedulingPolicy(ctx, api, clusterId, item)
+		_, err := SaveClusterSchedulingPolicy(ctx, api, clusterId, item)
 		if err != nil {
 			return diag.Diagnostics{
 				diag.Diagnostic{
@@ -1875,7 +1896,7 @@ func ListClusterSchedulingPolicy(ctx context.Context, api cluster.IClusterAPI, c
 	return policyList, policyExtraInfo, nil
 }
 
-func SaveClusterSchedulingPolicy(ctx context.Context, api cluster.IClusterAPI, clusterId string, m map[string]interface{}) error {
+func SaveClusterSchedulingPolicy(ctx context.Context, api cluster.IClusterAPI, clusterId string, m map[string]interface{}) (string, error) {
 
 	activeDays := m["active_days"].(*schema.Set).List()
 	dayArr := make([]string, 0)
@@ -1895,10 +1916,10 @@ func SaveClusterSchedulingPolicy(ctx context.Context, api cluster.IClusterAPI, c
 	})
 	if err != nil {
 		log.Printf("[ERROR] save cluster scheduling policy failed,cluster[%s] paramMap:%+v  err:%+v", clusterId, m, err)
-		return err
+		return "", err
 	}
 	log.Printf("[DEBUG] save cluster scheduling policy, cluster[%s] paramMap:%+v resp:%+v", clusterId, m, resp)
-	return nil
+	return resp.PolicyId, nil
 }
 
 func ModifyClusterSchedulingPolicy(ctx context.Context, api cluster.IClusterAPI, clusterId string, policyId string, m map[string]interface{}) error {
@@ -1950,6 +1971,16 @@ type waitStateReq struct {
 	targetStates        []string
 }
 
+// timeoutFromContext returns the time left before the deadline of ctx. The SDK sets the deadline
+// from the `timeouts` of the running operation, so helpers without access to the resource data
+// still wait no longer than the user allowed.
+func timeoutFromContext(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return common.DefaultWaitTimeout
+}
+
 func WaitClusterStateChangeComplete(ctx context.Context, req *waitStateReq) (*cluster.GetStateResp, error) {
 	stateConf := &retry.StateChangeConf{
 		Pending:    req.pendingStates,
@@ -2060,7 +2091,7 @@ func SuspendWithContext(ctx context.Context, clusterAPI cluster.IClusterAPI, clu
 		clusterAPI:    clusterAPI,
 		actionID:      resp.ActionID,
 		clusterID:     clusterID,
-		timeout:       20 * time.Minute,
+		timeout:       timeoutFromContext(ctx),
 		pendingStates: []string{string(cluster.ClusterStateSuspending)},
 		targetStates:  []string{string(cluster.ClusterStateSuspended), string(cluster.ClusterStateAbnormal)},
 	})
@@ -2105,7 +2136,7 @@ func ResumeWithContext(ctx context.Context, clusterAPI cluster.IClusterAPI, clus
 		clusterAPI:    clusterAPI,
 		actionID:      resp.ActionID,
 		clusterID:     clusterID,
-		timeout:       20 * time.Minute,
+		timeout:       timeoutFromContext(ctx),
 		pendingStates: []string{string(cluster.ClusterStateResuming)},
 		targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 	})
@@ -2194,7 +2225,7 @@ func removeClusterLdapSSLCert(ctx context.Context, clusterAPI cluster.IClusterAP
 			clusterAPI: clusterAPI,
 			clusterID:  clusterId,
 			actionID:   resp.InfraActionId,
-			timeout:    30 * time.Minute,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterInfraActionStatePending),
 				string(cluster.ClusterInfraActionStateOngoing),
@@ -2251,7 +2282,7 @@ func configClusterLdapSSLCert(ctx context.Context, clusterAPI cluster.IClusterAP
 		clusterAPI: clusterAPI,
 		clusterID:  clusterId,
 		actionID:   resp.InfraActionId,
-		timeout:    30 * time.Minute,
+		timeout:    timeoutFromContext(ctx),
 		pendingStates: []string{
 			string(cluster.ClusterInfraActionStatePending),
 			string(cluster.ClusterInfraActionStateOngoing),
@@ -2331,7 +2362,7 @@ func removeClusterConfig(ctx context.Context, clusterAPI cluster.IClusterAPI, re
 			clusterAPI: clusterAPI,
 			clusterID:  req.ClusterID,
 			actionID:   resp.InfraActionId,
-			timeout:    30 * time.Minute,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterInfraActionStatePending),
 				string(cluster.ClusterInfraActionStateOngoing),
@@ -2387,7 +2418,7 @@ func configClusterConfig(ctx context.Context, clusterAPI cluster.IClusterAPI, re
 		clusterAPI: clusterAPI,
 		clusterID:  req.ClusterID,
 		actionID:   resp.InfraActionId,
-		timeout:    30 * time.Minute,
+		timeout:    timeoutFromContext(ctx),
 		pendingStates: []string{
 			string(cluster.ClusterInfraActionStatePending),
 			string(cluster.ClusterInfraActionStateOngoing),
@@ -2460,7 +2491,7 @@ func removeClusterRangerCert(ctx context.Context, clusterAPI cluster.IClusterAPI
 			clusterAPI: clusterAPI,
 			clusterID:  clusterId,
 			actionID:   resp.InfraActionId,
-			timeout:    30 * time.Minute,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterInfraActionStatePending),
 				string(cluster.ClusterInfraActionStateOngoing),
@@ -2532,7 +2563,7 @@ func configClusterRangerCert(ctx context.Context, clusterAPI cluster.IClusterAPI
 		clusterAPI: clusterAPI,
 		clusterID:  clusterId,
 		actionID:   resp.InfraActionId,
-		timeout:    30 * time.Minute,
+		timeout:    timeoutFromContext(ctx),
 		pendingStates: []string{
 			string(cluster.ClusterInfraActionStatePending),
 			string(cluster.ClusterInfraActionStateOngoing),
@@ -2568,27 +2599,45 @@ func configClusterRangerCert(ctx context.Context, clusterAPI cluster.IClusterAPI
 }
 
 type VolumeParamVerifyReq struct {
-	ClusterAPI   cluster.IClusterAPI
+	VmCatalog    *cluster.VmCatalog
+	NodeType     string
 	VolumeCate   string
 	VolumeConfig map[string]interface{}
+	Path         cty.Path
 }
 
 func VolumeParamVerify(ctx context.Context, req *VolumeParamVerifyReq) error {
-	clusterAPI := req.ClusterAPI
 	volumeCate := req.VolumeCate
 	volumeConfig := req.VolumeConfig
 	volumeSize := int64(volumeConfig["vol_size"].(int))
 	volumeNum := int32(1)
 	iops := int64(volumeConfig["iops"].(int))
 	throughput := int64(volumeConfig["throughput"].(int))
-	err := clusterAPI.VolumeParamVerification(ctx, &cluster.ModifyClusterVolumeReq{
+	err := req.VmCatalog.VerifyVolume(ctx, &cluster.ModifyClusterVolumeReq{
 		VmVolCate:  volumeCate,
 		VmVolSize:  volumeSize,
 		VmVolNum:   volumeNum,
 		Iops:       iops,
 		Throughput: throughput,
 	})
-	return err
+	if err != nil {
+		log.Printf("[ERROR] verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", req.NodeType, volumeCate, volumeConfig, err)
+		return req.Path.NewErrorf("verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", req.NodeType, volumeCate, volumeConfig, err)
+	}
+	return nil
+}
+
+// getVmInfo returns the vm info of vmCate from the shared vm catalog, errors are reported on the attribute at path.
+func getVmInfo(ctx context.Context, vmCatalog *cluster.VmCatalog, csp, region, processType, vmCate string, path cty.Path) (*cluster.VMInfo, error) {
+	vmInfo, err := vmCatalog.Get(ctx, csp, region, processType, vmCate)
+	if err != nil {
+		log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, vmCate, err)
+		return nil, path.NewErrorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, vmCate, err.Error())
+	}
+	if vmInfo == nil {
+		return nil, path.NewErrorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, vmCate)
+	}
+	return vmInfo, nil
 }
 
 func IsInternalTagKeys(csp, key string) bool {
@@ -2880,7 +2929,7 @@ func ApplyRangerV2(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterI
 			clusterAPI: clusterAPI,
 			clusterID:  clusterID,
 			actionID:   resp.InfraActionID,
-			timeout:    30 * time.Minute,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterInfraActionStatePending),
 				string(cluster.ClusterInfraActionStateOngoing),
@@ -2935,7 +2984,7 @@ func ClearRangerV2(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterI
 			clusterAPI: clusterAPI,
 			clusterID:  clusterID,
 			actionID:   resp.InfraActionID,
-			timeout:    30 * time.Minute,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterInfraActionStatePending),
 				string(cluster.ClusterInfraActionStateOngoing),
@@ -0,0 +1,345 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	CLUSTER_ACTION_RUN_SCRIPTS          = "run_scripts"
+	CLUSTER_ACTION_ROLLING_RESTART      = "rolling_restart"
+	CLUSTER_ACTION_REALLOCATE_ENDPOINTS = "reallocate_endpoints"
+	CLUSTER_ACTION_SUSPEND              = "suspend"
+	CLUSTER_ACTION_RESUME               = "resume"
+
+	CLUSTER_ACTION_STATUS_SUCCEEDED = "Succeeded"
+	CLUSTER_ACTION_STATUS_FAILED    = "Failed"
+)
+
+// celerdatabyoc_cluster_action runs a one-off operation on a cluster when it's created or its triggers change.
+// The plugin SDK doesn't support Terraform actions, so the operation is modelled as a resource whose arguments
+// all force a new resource.
+func resourceClusterAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterActionCreate,
+		ReadContext:   resourceClusterActionRead,
+		DeleteContext: resourceClusterActionDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					CLUSTER_ACTION_RUN_SCRIPTS,
+					CLUSTER_ACTION_ROLLING_RESTART,
+					CLUSTER_ACTION_REALLOCATE_ENDPOINTS,
+					CLUSTER_ACTION_SUSPEND,
+					CLUSTER_ACTION_RESUME,
+				}, false),
+			},
+			"scripts": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"script_path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"logs_dir": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+					},
+				},
+			},
+			"run_scripts_parallel": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"node_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{NODE_TYPE_COORDINATOR, NODE_TYPE_COMPUTE}, false),
+			},
+			"warehouse_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that run the action again when they change.",
+			},
+			"action_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"started_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"finished_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"logs_dirs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+		},
+		CustomizeDiff: customizeClusterActionDiff,
+	}
+}
+
+func customizeClusterActionDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	action := d.Get("action").(string)
+
+	if action == CLUSTER_ACTION_RUN_SCRIPTS {
+		if len(d.Get("scripts").([]interface{})) == 0 {
+			return cty.GetAttrPath("scripts").NewErrorf("at least one script is required by the %s action", action)
+		}
+	} else if len(d.Get("scripts").([]interface{})) > 0 {
+		return cty.GetAttrPath("scripts").NewErrorf("scripts only takes effect for the %s action", CLUSTER_ACTION_RUN_SCRIPTS)
+	}
+
+	if action == CLUSTER_ACTION_ROLLING_RESTART {
+		if len(d.Get("node_type").(string)) == 0 {
+			return cty.GetAttrPath("node_type").NewErrorf("node_type is required by the %s action", action)
+		}
+		if d.Get("node_type").(string) == NODE_TYPE_COORDINATOR && len(d.Get("warehouse_id").(string)) > 0 {
+			return cty.GetAttrPath("warehouse_id").NewErrorf("warehouse_id only takes effect when node_type is %q", NODE_TYPE_COMPUTE)
+		}
+	} else {
+		if len(d.Get("node_type").(string)) > 0 {
+			return cty.GetAttrPath("node_type").NewErrorf("node_type only takes effect for the %s action", CLUSTER_ACTION_ROLLING_RESTART)
+		}
+		if len(d.Get("warehouse_id").(string)) > 0 {
+			return cty.GetAttrPath("warehouse_id").NewErrorf("warehouse_id only takes effect for the %s action", CLUSTER_ACTION_ROLLING_RESTART)
+		}
+	}
+	return nil
+}
+
+func resourceClusterActionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+	action := d.Get("action").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutCreate)); diags != nil {
+		return diags
+	}
+
+	startedAt := time.Now()
+	d.SetId(fmt.Sprintf("%s/%s/%s", clusterId, action, strconv.FormatInt(startedAt.UnixNano(), 10)))
+	d.Set("started_at", startedAt.UTC().Format(time.RFC3339))
+	d.Set("logs_dirs", []string{})
+
+	var actionId string
+	var err error
+	switch action {
+	case CLUSTER_ACTION_RUN_SCRIPTS:
+		err = runClusterScripts(ctx, clusterAPI, d)
+	case CLUSTER_ACTION_ROLLING_RESTART:
+		actionId, err = rollingRestartCluster(ctx, clusterAPI, d)
+	case CLUSTER_ACTION_REALLOCATE_ENDPOINTS:
+		err = reallocateClusterEndpoints(ctx, clusterAPI, clusterId)
+	case CLUSTER_ACTION_SUSPEND:
+		actionId, err = changeClusterState(ctx, clusterAPI, clusterId, true)
+	case CLUSTER_ACTION_RESUME:
+		actionId, err = changeClusterState(ctx, clusterAPI, clusterId, false)
+	}
+
+	d.Set("action_id", actionId)
+	d.Set("finished_at", time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		log.Printf("[ERROR] %s of cluster (%s) failed, action id:%s err:%+v", action, clusterId, actionId, err)
+		d.Set("status", CLUSTER_ACTION_STATUS_FAILED)
+		d.Set("message", err.Error())
+		// The failed action is kept in the state, so the next apply replaces the tainted resource and runs it again.
+		return diag.FromErr(fmt.Errorf("%s of cluster (%s) failed: %s", action, clusterId, err.Error()))
+	}
+
+	d.Set("status", CLUSTER_ACTION_STATUS_SUCCEEDED)
+	d.Set("message", "")
+	return nil
+}
+
+func resourceClusterActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	// The outcome of the action doesn't change, only check that the cluster still exists.
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing action (%s) from state", clusterId, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if resp.Cluster.ClusterState == cluster.ClusterStateReleased {
+		log.Printf("[WARN] Cluster (%s) is released, removing action (%s) from state", clusterId, d.Id())
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceClusterActionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// An action can't be undone, removing it only forgets its outcome.
+	d.SetId("")
+	return nil
+}
+
+// runClusterScripts runs the scripts on the nodes of the cluster.
+func runClusterScripts(ctx context.Context, clusterAPI cluster.IClusterAPI, d *schema.ResourceData) error {
+	scripts := make([]*cluster.Script, 0)
+	logsDirs := make([]string, 0)
+	for _, v := range d.Get("scripts").([]interface{}) {
+		scriptMap := v.(map[string]interface{})
+		scripts = append(scripts, &cluster.Script{
+			ScriptPath: scriptMap["script_path"].(string),
+			LogsDir:    scriptMap["logs_dir"].(string),
+		})
+		logsDirs = append(logsDirs, scriptMap["logs_dir"].(string))
+	}
+	d.Set("logs_dirs", logsDirs)
+
+	return runScripts(ctx, clusterAPI, d.Get("cluster_id").(string), scripts, d.Get("run_scripts_parallel").(bool))
+}
+
+// rollingRestartCluster re-applies the custom configs of the nodes, which restarts them in a rolling manner.
+// There is no API to restart the nodes, so it fails if applying the configs doesn't start an infra action,
+// which means the nodes were not restarted.
+func rollingRestartCluster(ctx context.Context, clusterAPI cluster.IClusterAPI, d *schema.ResourceData) (string, error) {
+	clusterId := d.Get("cluster_id").(string)
+	resp, err := clusterAPI.ApplyCustomConfig(ctx, &cluster.ApplyCustomConfigReq{
+		ClusterID:   clusterId,
+		ConfigType:  nodeConfigTypes[d.Get("node_type").(string)],
+		WarehouseID: d.Get("warehouse_id").(string),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[DEBUG] rolling restart cluster (%s), resp:%+v", clusterId, resp)
+	if len(resp.InfraActionId) == 0 {
+		return "", fmt.Errorf("applying the %s node configs didn't restart the nodes, the configs may be already applied", d.Get("node_type").(string))
+	}
+	return resp.InfraActionId, waitInfraAction(ctx, clusterAPI, clusterId, resp.InfraActionId)
+}
+
+func reallocateClusterEndpoints(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string) error {
+	err := clusterAPI.AllocateClusterEndpoints(ctx, &cluster.AllocateClusterEndpointsReq{
+		ClusterId: clusterId,
+	})
+	if err != nil {
+		return err
+	}
+
+	stateResp, err := WaitClusterEndpointsStateChangeComplete(ctx, &waitEndpointsStateReq{
+		clusterAPI: clusterAPI,
+		clusterId:  clusterId,
+		timeout:    timeoutFromContext(ctx),
+		pendingStates: []string{
+			strconv.Itoa(int(cluster.DomainAllocateStateUnknown)),
+			strconv.Itoa(int(cluster.DomainAllocateStateOngoing)),
+		},
+		targetStates: []string{
+			strconv.Itoa(int(cluster.DomainAllocateStateSucceeded)),
+			strconv.Itoa(int(cluster.DomainAllocateStateFailed)),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for cluster (%s) endpoints allocation: %s", clusterId, err)
+	}
+
+	if stateResp.State != cluster.DomainAllocateStateSucceeded {
+		return errors.New("failed to allocate cluster endpoints")
+	}
+	return nil
+}
+
+// changeClusterState suspends or resumes the cluster and returns the id of the action.
+func changeClusterState(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, suspend bool) (string, error) {
+	req := &waitStateReq{
+		clusterAPI: clusterAPI,
+		clusterID:  clusterId,
+		timeout:    timeoutFromContext(ctx),
+	}
+	if suspend {
+		resp, err := clusterAPI.Suspend(ctx, &cluster.SuspendReq{ClusterID: clusterId})
+		if err != nil {
+			return "", err
+		}
+		req.actionID = resp.ActionID
+		req.pendingStates = []string{string(cluster.ClusterStateSuspending)}
+		req.targetStates = []string{string(cluster.ClusterStateSuspended), string(cluster.ClusterStateAbnormal)}
+	} else {
+		resp, err := clusterAPI.Resume(ctx, &cluster.ResumeReq{ClusterID: clusterId})
+		if err != nil {
+			return "", err
+		}
+		req.actionID = resp.ActionID
+		req.pendingStates = []string{string(cluster.ClusterStateResuming)}
+		req.targetStates = []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)}
+	}
+
+	stateResp, err := WaitClusterStateChangeComplete(ctx, req)
+	if err != nil {
+		return req.actionID, fmt.Errorf("waiting for cluster (%s) change complete: %s", clusterId, err)
+	}
+	if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
+		return req.actionID, errors.New(stateResp.AbnormalReason)
+	}
+	return req.actionID, nil
+}
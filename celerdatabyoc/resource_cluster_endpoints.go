@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -60,6 +61,11 @@ func resourceClusterEndpoints() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
+		},
 	}
 }
 
@@ -92,7 +98,7 @@ func resourceClusterEndpointsCreate(ctx context.Context, d *schema.ResourceData,
 	stateResp, err := WaitClusterEndpointsStateChangeComplete(ctx, &waitEndpointsStateReq{
 		clusterAPI: clusterAPI,
 		clusterId:  clusterId,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutCreate),
 		pendingStates: []string{
 			strconv.Itoa(int(cluster.DomainAllocateStateUnknown)),
 			strconv.Itoa(int(cluster.DomainAllocateStateOngoing)),
@@ -125,7 +131,7 @@ func resourceClusterEndpointsRead(ctx context.Context, d *schema.ResourceData, m
 	stateResp, err := WaitClusterEndpointsStateChangeComplete(ctx, &waitEndpointsStateReq{
 		clusterAPI: clusterAPI,
 		clusterId:  clusterId,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutRead),
 		pendingStates: []string{
 			strconv.Itoa(int(cluster.DomainAllocateStateUnknown)),
 			strconv.Itoa(int(cluster.DomainAllocateStateOngoing)),
@@ -0,0 +1,330 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func resourceClusterGlobalVariables() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterGlobalVariablesCreate,
+		ReadContext:   resourceClusterGlobalVariablesRead,
+		UpdateContext: resourceClusterGlobalVariablesUpdate,
+		DeleteContext: resourceClusterGlobalVariablesDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"variables": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The global session variables of the cluster. The variables removed from the map are reset to their defaults.",
+			},
+			"track_unmanaged_variables": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to report the variables that are not declared in `variables` and whose values changed since `variables_snapshot` was taken.",
+			},
+			"variables_snapshot": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The values of all variables when `track_unmanaged_variables` was enabled. It is a snapshot of the values at that time, not the defaults of the cluster: the variables that were already changed then are not reported.",
+			},
+			"changed_unmanaged_variables": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The variables that are not declared in `variables` and whose values differ from `variables_snapshot`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceClusterGlobalVariablesImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Update: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
+		},
+	}
+}
+
+// resourceClusterGlobalVariablesImport accepts `<cluster_id>` or `<cluster_id>/<name>,<name>...`, the names are
+// the variables to manage.
+func resourceClusterGlobalVariablesImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts[0]) == 0 {
+		return nil, fmt.Errorf("invalid import id %q, expected <cluster_id> or <cluster_id>/<variable_name>,<variable_name>", d.Id())
+	}
+
+	variables := make(map[string]string)
+	if len(parts) == 2 {
+		for _, name := range strings.Split(parts[1], ",") {
+			if name = strings.TrimSpace(name); len(name) > 0 {
+				variables[name] = ""
+			}
+		}
+	}
+
+	d.SetId(parts[0])
+	d.Set("cluster_id", parts[0])
+	d.Set("variables", variables)
+	d.Set("track_unmanaged_variables", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceClusterGlobalVariablesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	if diags := waitClusterRunning(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutCreate)); diags != nil {
+		return diags
+	}
+
+	d.SetId(clusterId)
+	return applyGlobalVariables(ctx, clusterAPI, d, m)
+}
+
+func resourceClusterGlobalVariablesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		if !d.IsNewResource() && status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing global variables from state", clusterId)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if resp.Cluster.ClusterState == cluster.ClusterStateReleased {
+		log.Printf("[WARN] Cluster (%s) is released, removing global variables from state", clusterId)
+		d.SetId("")
+		return nil
+	}
+	if resp.Cluster.ClusterState != cluster.ClusterStateRunning {
+		// The variables can only be queried from a running cluster, keep the state as is.
+		log.Printf("[DEBUG] cluster (%s) is %s, skip reading global variables", clusterId, resp.Cluster.ClusterState)
+		return nil
+	}
+
+	variables := make(map[string]string)
+	if v := d.Get("variables").(map[string]interface{}); len(v) > 0 {
+		sessionVariablesResp, diags := GetGlobalSqlSessionVariables(ctx, clusterAPI, clusterId, v)
+		if diags != nil {
+			return diags
+		}
+		for k := range v {
+			if value, ok := sessionVariablesResp.Variables[k]; ok {
+				variables[k] = value
+			}
+		}
+	}
+	d.Set("variables", variables)
+
+	if !d.Get("track_unmanaged_variables").(bool) {
+		d.Set("variables_snapshot", nil)
+		d.Set("changed_unmanaged_variables", nil)
+		return nil
+	}
+
+	// Without variable names, all the variables of the cluster are returned.
+	allResp, err := clusterAPI.GetGlobalSqlSessionVariables(ctx, &cluster.GetGlobalSqlSessionVariablesReq{ClusterId: clusterId})
+	if err != nil {
+		log.Printf("[ERROR] query cluster global session variables failed, err:%+v", err)
+		return diag.FromErr(err)
+	}
+
+	snapshot := make(map[string]string)
+	for k, v := range d.Get("variables_snapshot").(map[string]interface{}) {
+		snapshot[k] = v.(string)
+	}
+	if len(snapshot) == 0 {
+		snapshot = allResp.Variables
+		d.Set("variables_snapshot", snapshot)
+	}
+
+	unmanaged := make(map[string]string)
+	names := make([]string, 0)
+	for k, v := range allResp.Variables {
+		if _, ok := variables[k]; ok {
+			continue
+		}
+		if sv, ok := snapshot[k]; ok && sv != v {
+			unmanaged[k] = v
+			names = append(names, fmt.Sprintf("%s (%s -> %s)", k, sv, v))
+		}
+	}
+	d.Set("changed_unmanaged_variables", unmanaged)
+
+	if len(names) > 0 {
+		sort.Strings(names)
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unmanaged global session variables changed",
+				Detail:   fmt.Sprintf("The following variables of cluster (%s) are not declared in `variables` and changed since the snapshot was taken: %s", clusterId, strings.Join(names, ", ")),
+			},
+		}
+	}
+	return nil
+}
+
+func resourceClusterGlobalVariablesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+
+	if diags := waitClusterRunning(ctx, clusterAPI, d.Id(), d.Timeout(schema.TimeoutUpdate)); diags != nil {
+		return diags
+	}
+
+	return applyGlobalVariables(ctx, clusterAPI, d, m)
+}
+
+func resourceClusterGlobalVariablesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	state, diags := waitClusterState(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutDelete))
+	if diags != nil {
+		return diags
+	}
+	if state == string(cluster.ClusterStateReleased) {
+		d.SetId("")
+		return nil
+	}
+	if state != string(cluster.ClusterStateRunning) {
+		return diag.FromErr(fmt.Errorf("the global variables of cluster (%s) can only be reset when it's running, the cluster is %s", clusterId, state))
+	}
+
+	variables := make([]string, 0)
+	for k := range d.Get("variables").(map[string]interface{}) {
+		variables = append(variables, k)
+	}
+	if len(variables) > 0 {
+		resp, err := clusterAPI.ResetGlobalSqlSessionVariables(ctx, &cluster.ResetGlobalSqlSessionVariablesReq{
+			ClusterId: clusterId,
+			Variables: variables,
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to reset global session variables of cluster (%s): %s", clusterId, err.Error()))
+		}
+		if resp.HasFailed {
+			return diag.FromErr(fmt.Errorf("failed to reset global session variables %v of cluster (%s): %s", resp.FailedVariables, clusterId, strings.Join(resp.ErrMsgArr, "\n")))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// applyGlobalVariables sets the changed variables and resets the removed ones. The variables are read back
+// afterwards, so a partially applied change leaves the actual values in the state: the variables that failed to
+// be set keep their current values and the ones that failed to be reset stay managed.
+func applyGlobalVariables(ctx context.Context, clusterAPI cluster.IClusterAPI, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterId := d.Id()
+
+	o, n := d.GetChange("variables")
+	oMap := make(map[string]string)
+	for k, v := range o.(map[string]interface{}) {
+		oMap[k] = v.(string)
+	}
+	nMap := make(map[string]string)
+	for k, v := range n.(map[string]interface{}) {
+		nMap[k] = v.(string)
+	}
+
+	updatedVariables := make(map[string]string)
+	for k, nv := range nMap {
+		if ov, ok := oMap[k]; !ok || nv != ov {
+			updatedVariables[k] = nv
+		}
+	}
+	removedVariables := make([]string, 0)
+	for k := range oMap {
+		if _, ok := nMap[k]; !ok {
+			removedVariables = append(removedVariables, k)
+		}
+	}
+
+	errMsgs := make([]string, 0)
+	managed := make(map[string]interface{}, len(nMap))
+	for k, v := range nMap {
+		managed[k] = v
+	}
+
+	if len(updatedVariables) > 0 {
+		resp, err := clusterAPI.SetGlobalSqlSessionVariables(ctx, &cluster.SetGlobalSqlSessionVariablesReq{
+			ClusterId: clusterId,
+			Variables: updatedVariables,
+		})
+		if err != nil {
+			errMsgs = append(errMsgs, fmt.Sprintf("failed to set variables: %s", err.Error()))
+		} else if resp.HasFailed {
+			errMsgs = append(errMsgs, fmt.Sprintf("failed to set variables %v: %s", resp.FailedVariables, strings.Join(resp.ErrMsgArr, "\n")))
+		}
+	}
+
+	if len(removedVariables) > 0 {
+		resp, err := clusterAPI.ResetGlobalSqlSessionVariables(ctx, &cluster.ResetGlobalSqlSessionVariablesReq{
+			ClusterId: clusterId,
+			Variables: removedVariables,
+		})
+		if err != nil {
+			errMsgs = append(errMsgs, fmt.Sprintf("failed to reset variables: %s", err.Error()))
+			for _, k := range removedVariables {
+				managed[k] = oMap[k]
+			}
+		} else if resp.HasFailed {
+			errMsgs = append(errMsgs, fmt.Sprintf("failed to reset variables %v: %s", resp.FailedVariables, strings.Join(resp.ErrMsgArr, "\n")))
+			for _, k := range resp.FailedVariables {
+				managed[k] = oMap[k]
+			}
+		}
+	}
+
+	d.Set("variables", managed)
+	diags := resourceClusterGlobalVariablesRead(ctx, d, m)
+
+	if len(errMsgs) > 0 {
+		log.Printf("[ERROR] update global session variables of cluster (%s) failed: %s", clusterId, strings.Join(errMsgs, "; "))
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to update global session variables",
+			Detail:   strings.Join(errMsgs, "\n"),
+		})
+	}
+	return diags
+}
+
+// waitClusterRunning waits for the ongoing operation of the cluster to finish and fails unless the cluster is running.
+func waitClusterRunning(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, timeout time.Duration) diag.Diagnostics {
+	state, diags := waitClusterState(ctx, clusterAPI, clusterId, timeout)
+	if diags != nil {
+		return diags
+	}
+
+	if state != string(cluster.ClusterStateRunning) {
+		return diag.FromErr(fmt.Errorf("cluster (%s) is %s, it must be running", clusterId, state))
+	}
+	return nil
+}
@@ -0,0 +1,471 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ldapConfigKeyPrefix = "authentication_ldap_"
+
+	ldapServerHostKey     = "authentication_ldap_simple_server_host"
+	ldapServerPortKey     = "authentication_ldap_simple_server_port"
+	ldapBindRootDnKey     = "authentication_ldap_simple_bind_root_dn"
+	ldapBindRootPwdKey    = "authentication_ldap_simple_bind_root_pwd"
+	ldapBindBaseDnKey     = "authentication_ldap_simple_bind_base_dn"
+	ldapUserSearchAttrKey = "authentication_ldap_simple_user_search_attr"
+)
+
+func resourceClusterLdap() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterLdapCreate,
+		ReadContext:   resourceClusterLdapRead,
+		UpdateContext: resourceClusterLdapUpdate,
+		DeleteContext: resourceClusterLdapDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"server_host": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"server_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      389,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"bind_root_dn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"bind_root_pwd": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"bind_root_pwd_wo":         writeOnlySchema("bind_root_pwd", "The password of `bind_root_dn`.", validation.ToDiagFunc(validation.StringIsNotEmpty)),
+			"bind_root_pwd_wo_version": writeOnlyVersionSchema("bind_root_pwd"),
+			"base_dn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"user_search_attr": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "uid",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"additional_configs": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Other LDAP related coordinator node configs, such as the group mapping settings.",
+				ValidateDiagFunc: validation.MapKeyMatch(
+					regexp.MustCompile("^"+ldapConfigKeyPrefix), "key must start with "+ldapConfigKeyPrefix),
+			},
+			"ssl_certs": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateS3Path,
+				},
+			},
+			"config_keys": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the coordinator node configs written by the resource.",
+			},
+		},
+		ValidateRawResourceConfigFuncs: preferWriteOnly("bind_root_pwd"),
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if d.HasChange("additional_configs") {
+				return d.SetNewComputed("config_keys")
+			}
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Delete: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+		},
+	}
+}
+
+// validateS3Path validates the S3 path of a certificate, like the `ldap_ssl_certs` of the cluster resources.
+func validateS3Path(i interface{}, k string) (warnings []string, errors []error) {
+	value, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return warnings, errors
+	}
+
+	if len(value) == 0 {
+		errors = append(errors, fmt.Errorf("%s`s value cann`t be empty", k))
+	} else if !CheckS3Path(value) {
+		errors = append(errors, fmt.Errorf("for %s invalid s3 path:%s", k, value))
+	}
+	return warnings, errors
+}
+
+// ldapConfigs returns the coordinator node configs of the LDAP authentication.
+func ldapConfigs(d *schema.ResourceData) map[string]string {
+	configs := map[string]string{
+		ldapServerHostKey:     d.Get("server_host").(string),
+		ldapServerPortKey:     strconv.Itoa(d.Get("server_port").(int)),
+		ldapBindRootDnKey:     d.Get("bind_root_dn").(string),
+		ldapBindRootPwdKey:    getSecret(d, "bind_root_pwd"),
+		ldapBindBaseDnKey:     d.Get("base_dn").(string),
+		ldapUserSearchAttrKey: d.Get("user_search_attr").(string),
+	}
+	for k, v := range d.Get("additional_configs").(map[string]interface{}) {
+		configs[k] = v.(string)
+	}
+	return configs
+}
+
+// ldapConfigKeys returns the names of the coordinator node configs written by the resource. The state of an
+// imported resource doesn't have them yet, the keys of the current configuration are used instead.
+func ldapConfigKeys(d *schema.ResourceData) []string {
+	keys := make([]string, 0)
+	for _, v := range d.Get("config_keys").(*schema.Set).List() {
+		keys = append(keys, v.(string))
+	}
+	if len(keys) > 0 {
+		return keys
+	}
+	for k := range ldapConfigs(d) {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// ldapBindRootPwdHasChange reports whether the password must be applied again. Switching from `bind_root_pwd` to
+// `bind_root_pwd_wo` with the same password doesn't restart the coordinator nodes.
+func ldapBindRootPwdHasChange(d *schema.ResourceData) bool {
+	if movedToWriteOnly(d, "bind_root_pwd") && !d.HasChange(writeOnlyVersionKey("bind_root_pwd")) {
+		o, _ := d.GetChange("bind_root_pwd")
+		return o.(string) != getWriteOnly(d, writeOnlyKey("bind_root_pwd"))
+	}
+	return secretHasChange(d, "bind_root_pwd")
+}
+
+func ldapSSLCerts(d *schema.ResourceData) []string {
+	sslCerts := make([]string, 0)
+	for _, v := range d.Get("ssl_certs").(*schema.Set).List() {
+		sslCerts = append(sslCerts, v.(string))
+	}
+	return sslCerts
+}
+
+func resourceClusterLdapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutCreate)); diags != nil {
+		return diags
+	}
+
+	if sslCerts := ldapSSLCerts(d); len(sslCerts) > 0 {
+		if err := asError(UpsertClusterLdapSslCert(ctx, clusterAPI, clusterId, sslCerts, false)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(clusterId)
+
+	configs := ldapConfigs(d)
+	if err := applyLdapConfigs(ctx, clusterAPI, clusterId, configs); err != nil {
+		return diag.FromErr(err)
+	}
+	setLdapConfigKeys(d, configs)
+
+	return resourceClusterLdapRead(ctx, d, m)
+}
+
+func resourceClusterLdapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		if !d.IsNewResource() && status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing LDAP settings from state", clusterId)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if resp.Cluster.ClusterState == cluster.ClusterStateReleased {
+		log.Printf("[WARN] Cluster (%s) is released, removing LDAP settings from state", clusterId)
+		d.SetId("")
+		return nil
+	}
+
+	configsResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
+		ClusterID:  clusterId,
+		ConfigType: cluster.CustomConfigTypeFE,
+	})
+	if err != nil {
+		log.Printf("[ERROR] query cluster custom config failed, err:%+v", err)
+		return diag.FromErr(err)
+	}
+
+	configs := configsResp.Configs
+	if _, ok := configs[ldapServerHostKey]; !ok && !d.IsNewResource() {
+		log.Printf("[WARN] LDAP settings of cluster (%s) not found, removing from state", clusterId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("server_host", configs[ldapServerHostKey])
+	if port, err := strconv.Atoi(configs[ldapServerPortKey]); err == nil {
+		d.Set("server_port", port)
+	}
+	d.Set("bind_root_dn", configs[ldapBindRootDnKey])
+	d.Set("base_dn", configs[ldapBindBaseDnKey])
+	d.Set("user_search_attr", configs[ldapUserSearchAttrKey])
+
+	additionalConfigs := make(map[string]string)
+	for k := range d.Get("additional_configs").(map[string]interface{}) {
+		if v, ok := configs[k]; ok {
+			additionalConfigs[k] = v
+		}
+	}
+	d.Set("additional_configs", additionalConfigs)
+	d.Set("ssl_certs", resp.Cluster.LdapSslCerts)
+
+	configKeys := make([]string, 0)
+	for _, k := range ldapConfigKeys(d) {
+		if _, ok := configs[k]; ok {
+			configKeys = append(configKeys, k)
+		}
+	}
+	d.Set("config_keys", configKeys)
+	return nil
+}
+
+func resourceClusterLdapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutUpdate)); diags != nil {
+		return diags
+	}
+
+	if d.HasChange("ssl_certs") {
+		if err := asError(UpsertClusterLdapSslCert(ctx, clusterAPI, clusterId, ldapSSLCerts(d), true)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges("server_host", "server_port", "bind_root_dn", "base_dn", "user_search_attr", "additional_configs") || ldapBindRootPwdHasChange(d) {
+		configs := ldapConfigs(d)
+		removedKeys := make([]string, 0)
+		for _, k := range ldapConfigKeys(d) {
+			if _, ok := configs[k]; !ok {
+				removedKeys = append(removedKeys, k)
+			}
+		}
+
+		if err := applyLdapConfigs(ctx, clusterAPI, clusterId, configs, removedKeys...); err != nil {
+			return diag.FromErr(err)
+		}
+		setLdapConfigKeys(d, configs)
+	}
+
+	return resourceClusterLdapRead(ctx, d, m)
+}
+
+func resourceClusterLdapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	state, diags := waitClusterState(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutDelete))
+	if diags != nil {
+		return diags
+	}
+	if state == string(cluster.ClusterStateReleased) {
+		d.SetId("")
+		return nil
+	}
+
+	if err := applyLdapConfigs(ctx, clusterAPI, clusterId, nil, ldapConfigKeys(d)...); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(ldapSSLCerts(d)) > 0 {
+		if err := asError(removeClusterLdapSSLCert(ctx, clusterAPI, clusterId)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func setLdapConfigKeys(d *schema.ResourceData, configs map[string]string) {
+	keys := make([]string, 0, len(configs))
+	for k := range configs {
+		keys = append(keys, k)
+	}
+	d.Set("config_keys", keys)
+}
+
+// applyLdapConfigs merges the LDAP configs into the coordinator node configs of the cluster, which are shared
+// with the other coordinator node configs, and applies them.
+func applyLdapConfigs(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, ldapConfigs map[string]string, removedKeys ...string) error {
+	configsResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
+		ClusterID:  clusterId,
+		ConfigType: cluster.CustomConfigTypeFE,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query the coordinator node configs of cluster (%s): %s", clusterId, err.Error())
+	}
+
+	configs := make(map[string]string)
+	for k, v := range configsResp.Configs {
+		configs[k] = v
+	}
+	for _, k := range removedKeys {
+		delete(configs, k)
+	}
+	for k, v := range ldapConfigs {
+		configs[k] = v
+	}
+
+	if len(configs) == 0 {
+		resp, err := clusterAPI.RemoveClusterConfig(ctx, &cluster.RemoveClusterConfigReq{
+			ClusterID:  clusterId,
+			ConfigType: cluster.CustomConfigTypeFE,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove the LDAP settings of cluster (%s): %s", clusterId, err.Error())
+		}
+		if len(resp.InfraActionId) > 0 {
+			return waitInfraAction(ctx, clusterAPI, clusterId, resp.InfraActionId)
+		}
+		return nil
+	}
+
+	err = clusterAPI.UpdateCustomConfig(ctx, &cluster.SaveCustomConfigReq{
+		ClusterID:  clusterId,
+		ConfigType: cluster.CustomConfigTypeFE,
+		Configs:    configs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save the LDAP settings of cluster (%s): %s", clusterId, err.Error())
+	}
+
+	resp, err := clusterAPI.ApplyCustomConfig(ctx, &cluster.ApplyCustomConfigReq{
+		ClusterID:  clusterId,
+		ConfigType: cluster.CustomConfigTypeFE,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply the LDAP settings of cluster (%s): %s", clusterId, err.Error())
+	}
+	if len(resp.InfraActionId) > 0 {
+		if err := waitInfraAction(ctx, clusterAPI, clusterId, resp.InfraActionId); err != nil {
+			return fmt.Errorf("failed to apply the LDAP settings of cluster (%s): %s", clusterId, err.Error())
+		}
+	}
+	return nil
+}
+
+// isLdapConfigKey reports whether the coordinator node config key is managed by celerdatabyoc_cluster_ldap.
+func isLdapConfigKey(k string) bool {
+	return strings.HasPrefix(k, ldapConfigKeyPrefix)
+}
+
+// withoutLdapConfigs drops the LDAP configs from the coordinator node configs read from the cluster, unless they're
+// declared in the configs of the resource. They're managed by celerdatabyoc_cluster_ldap and include the bind password.
+func withoutLdapConfigs(configs map[string]string, declared interface{}) map[string]string {
+	declaredMap, _ := declared.(map[string]interface{})
+	ret := make(map[string]string, len(configs))
+	for k, v := range configs {
+		if _, ok := declaredMap[k]; isLdapConfigKey(k) && !ok {
+			continue
+		}
+		ret[k] = v
+	}
+	return ret
+}
+
+// mergeLdapConfigs adds the LDAP configs of current, the coordinator node configs of the cluster, that are missing
+// from the coordinator node configs to be written, so that replacing the coordinator node configs keeps the LDAP
+// settings. The LDAP configs in owned were written by the coordinator node configs themselves, they are dropped when
+// they're removed from configs.
+func mergeLdapConfigs(current, configs map[string]string, owned map[string]interface{}) map[string]string {
+	ret := make(map[string]string, len(configs))
+	for k, v := range current {
+		if _, ok := owned[k]; isLdapConfigKey(k) && !ok {
+			ret[k] = v
+		}
+	}
+	for k, v := range configs {
+		ret[k] = v
+	}
+	return ret
+}
+
+// mergeClusterLdapConfigs merges the LDAP configs of the cluster into the coordinator node configs to be written,
+// see mergeLdapConfigs.
+func mergeClusterLdapConfigs(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, configs map[string]string, owned map[string]interface{}) (map[string]string, error) {
+	configsResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
+		ClusterID:  clusterId,
+		ConfigType: cluster.CustomConfigTypeFE,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the coordinator node configs of cluster (%s): %s", clusterId, err.Error())
+	}
+	return mergeLdapConfigs(configsResp.Configs, configs, owned), nil
+}
+
+// asError converts the warning diagnostics of the helpers shared with the cluster resources into an error.
+func asError(diags diag.Diagnostics) error {
+	if len(diags) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(diags))
+	for _, v := range diags {
+		msgs = append(msgs, fmt.Sprintf("%s %s", v.Summary, v.Detail))
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
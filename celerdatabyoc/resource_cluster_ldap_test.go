@@ -0,0 +1,105 @@
+package celerdatabyoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithoutLdapConfigs(t *testing.T) {
+	configs := map[string]string{
+		"qe_max_connection":                        "1024",
+		"authentication_ldap_simple_server_host":   "ldap.example.com",
+		"authentication_ldap_simple_bind_root_pwd": "secret",
+	}
+
+	cases := []struct {
+		name     string
+		declared interface{}
+		want     map[string]string
+	}{
+		{
+			name:     "nothing declared",
+			declared: nil,
+			want:     map[string]string{"qe_max_connection": "1024"},
+		},
+		{
+			name:     "other configs declared",
+			declared: map[string]interface{}{"qe_max_connection": "1024"},
+			want:     map[string]string{"qe_max_connection": "1024"},
+		},
+		{
+			name:     "ldap config declared",
+			declared: map[string]interface{}{"authentication_ldap_simple_server_host": "ldap.example.com"},
+			want: map[string]string{
+				"qe_max_connection":                      "1024",
+				"authentication_ldap_simple_server_host": "ldap.example.com",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := withoutLdapConfigs(configs, tc.declared); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("withoutLdapConfigs() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeLdapConfigs(t *testing.T) {
+	current := map[string]string{
+		"qe_max_connection":                        "1024",
+		"authentication_ldap_simple_server_host":   "ldap.example.com",
+		"authentication_ldap_simple_bind_root_pwd": "secret",
+	}
+
+	cases := []struct {
+		name    string
+		configs map[string]string
+		owned   map[string]interface{}
+		want    map[string]string
+	}{
+		{
+			name:    "ldap configs kept",
+			configs: map[string]string{"qe_max_connection": "2048"},
+			owned:   map[string]interface{}{"qe_max_connection": "1024"},
+			want: map[string]string{
+				"qe_max_connection":                        "2048",
+				"authentication_ldap_simple_server_host":   "ldap.example.com",
+				"authentication_ldap_simple_bind_root_pwd": "secret",
+			},
+		},
+		{
+			name:    "all configs removed",
+			configs: map[string]string{},
+			owned:   map[string]interface{}{"qe_max_connection": "1024"},
+			want: map[string]string{
+				"authentication_ldap_simple_server_host":   "ldap.example.com",
+				"authentication_ldap_simple_bind_root_pwd": "secret",
+			},
+		},
+		{
+			name:    "declared ldap config overrides",
+			configs: map[string]string{"authentication_ldap_simple_server_host": "ldap2.example.com"},
+			owned:   map[string]interface{}{"authentication_ldap_simple_server_host": "ldap.example.com"},
+			want: map[string]string{
+				"authentication_ldap_simple_server_host":   "ldap2.example.com",
+				"authentication_ldap_simple_bind_root_pwd": "secret",
+			},
+		},
+		{
+			name:    "owned ldap config removed",
+			configs: map[string]string{},
+			owned:   map[string]interface{}{"authentication_ldap_simple_server_host": "ldap.example.com"},
+			want:    map[string]string{"authentication_ldap_simple_bind_root_pwd": "secret"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := mergeLdapConfigs(current, tc.configs, tc.owned); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("mergeLdapConfigs() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
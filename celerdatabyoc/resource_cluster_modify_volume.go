@@ -6,7 +6,7 @@ import (
 	"log"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
-	"time"
+	"terraform-provider-celerdatabyoc/common"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -61,6 +61,10 @@ func resourceClusterModifyVolume() *schema.Resource {
 				Description: "Modify volume detail execution result",
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
+		},
 	}
 }
 
@@ -121,7 +125,7 @@ func resourceClusterModifyVolumeCreate(ctx context.Context, d *schema.ResourceDa
 			clusterAPI: clusterAPI,
 			clusterID:  clusterId,
 			actionID:   infraActionId,
-			timeout:    30 * time.Minute,
+			timeout:    d.Timeout(schema.TimeoutCreate),
 			pendingStates: []string{
 				string(cluster.ClusterInfraActionStatePending),
 				string(cluster.ClusterInfraActionStateOngoing),
@@ -174,7 +178,7 @@ func resourceClusterModifyVolumeRead(ctx context.Context, d *schema.ResourceData
 			clusterAPI: clusterAPI,
 			clusterID:  clusterId,
 			actionID:   infraActionId,
-			timeout:    30 * time.Minute,
+			timeout:    d.Timeout(schema.TimeoutRead),
 			pendingStates: []string{
 				string(cluster.ClusterInfraActionStatePending),
 				string(cluster.ClusterInfraActionStateOngoing),
@@ -0,0 +1,394 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	NODE_TYPE_COORDINATOR = "coordinator"
+	NODE_TYPE_COMPUTE     = "compute"
+)
+
+var nodeConfigTypes = map[string]cluster.CustomConfigType{
+	NODE_TYPE_COORDINATOR: cluster.CustomConfigTypeFE,
+	NODE_TYPE_COMPUTE:     cluster.CustomConfigTypeBE,
+}
+
+func resourceClusterNodeConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterNodeConfigCreate,
+		ReadContext:   resourceClusterNodeConfigRead,
+		UpdateContext: resourceClusterNodeConfigUpdate,
+		DeleteContext: resourceClusterNodeConfigDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"node_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{NODE_TYPE_COORDINATOR, NODE_TYPE_COMPUTE}, false),
+			},
+			"warehouse_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The warehouse whose compute nodes are configured. Only takes effect when `node_type` is `compute`.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"configs": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"apply_immediately": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to apply the configs right away, which restarts the nodes in a rolling manner. When it's false, the configs are saved and take effect the next time the nodes restart.",
+			},
+			"last_edit_at": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_apply_at": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceClusterNodeConfigImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Delete: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if d.Get("node_type").(string) == NODE_TYPE_COORDINATOR && len(d.Get("warehouse_id").(string)) > 0 {
+				return cty.GetAttrPath("warehouse_id").NewErrorf("warehouse_id only takes effect when node_type is %q", NODE_TYPE_COMPUTE)
+			}
+			return nil
+		},
+	}
+}
+
+// nodeConfigId returns the id of a celerdatabyoc_cluster_node_config, which is also its import id.
+func nodeConfigId(clusterId, nodeType, warehouseId string) string {
+	if len(warehouseId) > 0 {
+		return strings.Join([]string{clusterId, nodeType, warehouseId}, "/")
+	}
+	return strings.Join([]string{clusterId, nodeType}, "/")
+}
+
+func resourceClusterNodeConfigImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) < 2 || len(parts) > 3 || len(parts[0]) == 0 {
+		return nil, fmt.Errorf("invalid import id %q, expected <cluster_id>/<node_type> or <cluster_id>/compute/<warehouse_id>", d.Id())
+	}
+	if _, ok := nodeConfigTypes[parts[1]]; !ok {
+		return nil, fmt.Errorf("invalid node type %q in import id, expected %q or %q", parts[1], NODE_TYPE_COORDINATOR, NODE_TYPE_COMPUTE)
+	}
+	if len(parts) == 3 && (parts[1] != NODE_TYPE_COMPUTE || len(parts[2]) == 0) {
+		return nil, fmt.Errorf("invalid import id %q, only the configs of compute nodes belong to a warehouse", d.Id())
+	}
+
+	d.Set("cluster_id", parts[0])
+	d.Set("node_type", parts[1])
+	if len(parts) == 3 {
+		d.Set("warehouse_id", parts[2])
+	}
+	d.Set("apply_immediately", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceClusterNodeConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+	nodeType := d.Get("node_type").(string)
+	warehouseId := d.Get("warehouse_id").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutCreate)); diags != nil {
+		return diags
+	}
+
+	if err := checkUnmanagedNodeConfigs(ctx, clusterAPI, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := saveNodeConfigs(ctx, clusterAPI, d); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(nodeConfigId(clusterId, nodeType, warehouseId))
+
+	if d.Get("apply_immediately").(bool) {
+		if err := applyNodeConfigs(ctx, clusterAPI, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceClusterNodeConfigRead(ctx, d, m)
+}
+
+func resourceClusterNodeConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	resp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
+		ClusterID:   clusterId,
+		ConfigType:  nodeConfigTypes[d.Get("node_type").(string)],
+		WarehouseID: d.Get("warehouse_id").(string),
+	})
+	if err != nil {
+		if !d.IsNewResource() && status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing node config (%s) from state", clusterId, d.Id())
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[ERROR] query cluster custom config failed, err:%+v", err)
+		return diag.FromErr(err)
+	}
+
+	configs := resp.Configs
+	if d.Get("node_type").(string) == NODE_TYPE_COORDINATOR {
+		configs = withoutLdapConfigs(configs, d.Get("configs"))
+	}
+	if !d.IsNewResource() && len(configs) == 0 {
+		log.Printf("[WARN] Node config (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("configs", configs)
+	d.Set("last_edit_at", resp.LastEditAt)
+	d.Set("last_apply_at", resp.LastApplyAt)
+	return nil
+}
+
+func resourceClusterNodeConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutUpdate)); diags != nil {
+		return diags
+	}
+
+	// The configs saved by a previous apply with `apply_immediately = false` may still be pending.
+	pending := d.Get("last_edit_at").(int) > d.Get("last_apply_at").(int)
+	if d.HasChange("configs") {
+		if err := saveNodeConfigs(ctx, clusterAPI, d); err != nil {
+			return diag.FromErr(err)
+		}
+		pending = true
+	}
+
+	if d.Get("apply_immediately").(bool) && pending {
+		if err := applyNodeConfigs(ctx, clusterAPI, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceClusterNodeConfigRead(ctx, d, m)
+}
+
+func resourceClusterNodeConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+	configType := nodeConfigTypes[d.Get("node_type").(string)]
+	warehouseId := d.Get("warehouse_id").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	state, diags := waitClusterState(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutDelete))
+	if diags != nil {
+		return diags
+	}
+	if state == string(cluster.ClusterStateReleased) {
+		log.Printf("[WARN] Cluster (%s) is released, so is node config (%s)", clusterId, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// The LDAP settings of celerdatabyoc_cluster_ldap are kept in the coordinator node configs.
+	remainingConfigs := map[string]string{}
+	if d.Get("node_type").(string) == NODE_TYPE_COORDINATOR {
+		var err error
+		remainingConfigs, err = mergeClusterLdapConfigs(ctx, clusterAPI, clusterId, remainingConfigs, d.Get("configs").(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if !d.Get("apply_immediately").(bool) || len(remainingConfigs) > 0 {
+		err := clusterAPI.UpdateCustomConfig(ctx, &cluster.SaveCustomConfigReq{
+			ClusterID:   clusterId,
+			ConfigType:  configType,
+			WarehouseID: warehouseId,
+			Configs:     remainingConfigs,
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to remove node config (%s): %s", d.Id(), err.Error()))
+		}
+		if d.Get("apply_immediately").(bool) {
+			if err := applyNodeConfigs(ctx, clusterAPI, d); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		d.SetId("")
+		return nil
+	}
+
+	resp, err := clusterAPI.RemoveClusterConfig(ctx, &cluster.RemoveClusterConfigReq{
+		ClusterID:   clusterId,
+		ConfigType:  configType,
+		WarehouseID: warehouseId,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to remove node config (%s): %s", d.Id(), err.Error()))
+	}
+	if len(resp.InfraActionId) > 0 {
+		if err := waitInfraAction(ctx, clusterAPI, clusterId, resp.InfraActionId); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to remove node config (%s): %s", d.Id(), err.Error()))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// checkUnmanagedNodeConfigs fails if the nodes already have configs that are not declared in the resource, they are
+// managed by the cluster resource or by another celerdatabyoc_cluster_node_config and saving the configs would
+// remove them.
+func checkUnmanagedNodeConfigs(ctx context.Context, clusterAPI cluster.IClusterAPI, d *schema.ResourceData) error {
+	clusterId := d.Get("cluster_id").(string)
+	nodeType := d.Get("node_type").(string)
+	resp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
+		ClusterID:   clusterId,
+		ConfigType:  nodeConfigTypes[nodeType],
+		WarehouseID: d.Get("warehouse_id").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the %s node configs of cluster (%s): %s", nodeType, clusterId, err.Error())
+	}
+
+	declared := d.Get("configs").(map[string]interface{})
+	unmanaged := make([]string, 0)
+	for k := range withoutLdapConfigs(resp.Configs, declared) {
+		if _, ok := declared[k]; !ok {
+			unmanaged = append(unmanaged, k)
+		}
+	}
+	if len(unmanaged) == 0 {
+		return nil
+	}
+
+	sort.Strings(unmanaged)
+	return fmt.Errorf("the %s nodes of cluster (%s) already have the configs %v, which are managed by the cluster resource or "+
+		"another celerdatabyoc_cluster_node_config. Declare them in this resource and add the configs argument of the nodes to "+
+		"the lifecycle.ignore_changes list of the cluster resource, or import the existing configs with terraform import",
+		nodeType, clusterId, unmanaged)
+}
+
+func saveNodeConfigs(ctx context.Context, clusterAPI cluster.IClusterAPI, d *schema.ResourceData) error {
+	configs := make(map[string]string)
+	for k, v := range d.Get("configs").(map[string]interface{}) {
+		configs[k] = v.(string)
+	}
+
+	if d.Get("node_type").(string) == NODE_TYPE_COORDINATOR {
+		o, _ := d.GetChange("configs")
+		var err error
+		configs, err = mergeClusterLdapConfigs(ctx, clusterAPI, d.Get("cluster_id").(string), configs, o.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+	}
+
+	req := &cluster.SaveCustomConfigReq{
+		ClusterID:   d.Get("cluster_id").(string),
+		ConfigType:  nodeConfigTypes[d.Get("node_type").(string)],
+		WarehouseID: d.Get("warehouse_id").(string),
+		Configs:     configs,
+	}
+	log.Printf("[DEBUG] save cluster custom config, req:%+v", req)
+	if err := clusterAPI.UpdateCustomConfig(ctx, req); err != nil {
+		log.Printf("[ERROR] save cluster custom config failed, req:%+v err:%+v", req, err)
+		return fmt.Errorf("failed to save the %s node configs of cluster (%s): %s", d.Get("node_type").(string), req.ClusterID, err.Error())
+	}
+	return nil
+}
+
+func applyNodeConfigs(ctx context.Context, clusterAPI cluster.IClusterAPI, d *schema.ResourceData) error {
+	clusterId := d.Get("cluster_id").(string)
+	resp, err := clusterAPI.ApplyCustomConfig(ctx, &cluster.ApplyCustomConfigReq{
+		ClusterID:   clusterId,
+		ConfigType:  nodeConfigTypes[d.Get("node_type").(string)],
+		WarehouseID: d.Get("warehouse_id").(string),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply the %s node configs of cluster (%s): %s", d.Get("node_type").(string), clusterId, err.Error())
+	}
+
+	log.Printf("[DEBUG] apply cluster custom config, node config:%s resp:%+v", d.Id(), resp)
+	if len(resp.InfraActionId) == 0 {
+		return nil
+	}
+	if err := waitInfraAction(ctx, clusterAPI, clusterId, resp.InfraActionId); err != nil {
+		return fmt.Errorf("failed to apply the %s node configs of cluster (%s): %s", d.Get("node_type").(string), clusterId, err.Error())
+	}
+	return nil
+}
+
+// waitInfraAction waits for the infra action of the cluster and fails if the action fails.
+func waitInfraAction(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId, actionId string) error {
+	resp, err := WaitClusterInfraActionStateChangeComplete(ctx, &waitStateReq{
+		clusterAPI: clusterAPI,
+		clusterID:  clusterId,
+		actionID:   actionId,
+		timeout:    timeoutFromContext(ctx),
+		pendingStates: []string{
+			string(cluster.ClusterInfraActionStatePending),
+			string(cluster.ClusterInfraActionStateOngoing),
+		},
+		targetStates: []string{
+			string(cluster.ClusterInfraActionStateSucceeded),
+			string(cluster.ClusterInfraActionStateCompleted),
+			string(cluster.ClusterInfraActionStateFailed),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for infra action[%s] of cluster (%s): %s", actionId, clusterId, err)
+	}
+
+	if resp.InfraActionState == string(cluster.ClusterInfraActionStateFailed) {
+		return errors.New(resp.ErrMsg)
+	}
+	return nil
+}
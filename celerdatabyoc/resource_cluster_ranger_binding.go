@@ -0,0 +1,250 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	rangerconfig "terraform-provider-celerdatabyoc/celerdata-sdk/service/ranger-config"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func resourceClusterRangerBinding() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterRangerBindingCreate,
+		ReadContext:   resourceClusterRangerBindingRead,
+		UpdateContext: resourceClusterRangerBindingUpdate,
+		DeleteContext: resourceClusterRangerBindingDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"ranger_config_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"ranger_certs_dir": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"ranger_config_checksum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The checksum of the file paths of the ranger config when it was last applied.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Delete: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			// The ranger config is applied by copying its files to the cluster, so the binding
+			// has to be applied again when the paths of the files change.
+			if len(d.Id()) == 0 || d.HasChange("ranger_config_id") || !d.NewValueKnown("ranger_config_id") {
+				return nil
+			}
+
+			checksum, err := rangerConfigChecksum(ctx, m.(*client.CelerdataClient), d.Get("ranger_config_id").(string))
+			if err != nil {
+				return err
+			}
+			if checksum != d.Get("ranger_config_checksum").(string) {
+				log.Printf("[DEBUG] file paths of ranger config (%s) changed, the binding of cluster (%s) will be applied again", d.Get("ranger_config_id"), d.Id())
+				return d.SetNewComputed("ranger_config_checksum")
+			}
+			return nil
+		},
+	}
+}
+
+// rangerConfigChecksum returns the checksum of the file paths of a ranger config.
+func rangerConfigChecksum(ctx context.Context, c *client.CelerdataClient, rangerConfigId string) (string, error) {
+	resp, err := rangerconfig.NewRangerConfigAPI(c).GetRangerConfig(ctx, rangerconfig.GetRangerConfigReq{BizID: rangerConfigId})
+	if err != nil {
+		return "", fmt.Errorf("failed to get ranger config (%s): %s", rangerConfigId, err.Error())
+	}
+	if resp == nil || resp.RangerConfig.BizID == "" {
+		return "", fmt.Errorf("ranger config (%s) not found", rangerConfigId)
+	}
+
+	conf := resp.RangerConfig
+	paths := []string{
+		conf.RangerStarrocksSecurityXmlPath,
+		conf.RangerStarrocksAuditXmlPath,
+		conf.RangerStarrocksPolicymgrSslXmlPath,
+		conf.RangerStarrocksTrustStorePath,
+		conf.RangerStarrocksTrustStoreCredPath,
+		conf.RangerStarrocksKeyStorePath,
+		conf.RangerStarrocksKeyStoreCredPath,
+		conf.RangerHiveSecurityXmlPath,
+		conf.RangerHiveAuditXmlPath,
+	}
+	hash := md5.Sum([]byte(strings.Join(paths, "\n")))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func resourceClusterRangerBindingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutCreate)); diags != nil {
+		return diags
+	}
+
+	if v, ok := d.GetOk("ranger_certs_dir"); ok {
+		if err := asError(UpsertClusterRangerCert(ctx, clusterAPI, clusterId, v.(string), false)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(clusterId)
+
+	if err := applyRangerBinding(ctx, m.(*client.CelerdataClient), d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClusterRangerBindingRead(ctx, d, m)
+}
+
+func resourceClusterRangerBindingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		if !d.IsNewResource() && status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing ranger binding from state", clusterId)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	rangerConfigResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
+		ClusterID:  clusterId,
+		ConfigType: cluster.CustomConfigTypeRangerV2,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get ranger config of cluster (%s): %s", clusterId, err.Error()))
+	}
+
+	rangerConfigId := rangerConfigResp.Configs["biz_id"]
+	if len(rangerConfigId) == 0 {
+		log.Printf("[WARN] No ranger config is applied to cluster (%s), removing ranger binding from state", clusterId)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("ranger_config_id", rangerConfigId)
+	d.Set("ranger_certs_dir", resp.Cluster.RangerCertsDirPath)
+
+	// An imported binding is assumed to be up to date with its ranger config.
+	if len(d.Get("ranger_config_checksum").(string)) == 0 {
+		checksum, err := rangerConfigChecksum(ctx, m.(*client.CelerdataClient), rangerConfigId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("ranger_config_checksum", checksum)
+	}
+	return nil
+}
+
+func resourceClusterRangerBindingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutUpdate)); diags != nil {
+		return diags
+	}
+
+	if d.HasChange("ranger_certs_dir") {
+		o, _ := d.GetChange("ranger_certs_dir")
+		if err := asError(UpsertClusterRangerCert(ctx, clusterAPI, clusterId, d.Get("ranger_certs_dir").(string), len(o.(string)) > 0)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges("ranger_config_id", "ranger_config_checksum") {
+		if err := applyRangerBinding(ctx, m.(*client.CelerdataClient), d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceClusterRangerBindingRead(ctx, d, m)
+}
+
+func resourceClusterRangerBindingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	state, diags := waitClusterState(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutDelete))
+	if diags != nil {
+		return diags
+	}
+	if state == string(cluster.ClusterStateReleased) {
+		log.Printf("[WARN] Cluster (%s) is released, so is its ranger binding", clusterId)
+		d.SetId("")
+		return nil
+	}
+
+	if err := asError(ClearRangerV2(ctx, clusterAPI, clusterId)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to clear ranger config of cluster (%s): %s", clusterId, err.Error()))
+	}
+
+	if len(d.Get("ranger_certs_dir").(string)) > 0 {
+		if err := asError(removeClusterRangerCert(ctx, clusterAPI, clusterId)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// applyRangerBinding applies the ranger config to the cluster and records the checksum of its file paths.
+func applyRangerBinding(ctx context.Context, c *client.CelerdataClient, d *schema.ResourceData) error {
+	clusterAPI := cluster.NewClustersAPI(c)
+	clusterId := d.Id()
+	rangerConfigId := d.Get("ranger_config_id").(string)
+
+	checksum, err := rangerConfigChecksum(ctx, c, rangerConfigId)
+	if err != nil {
+		return err
+	}
+
+	if err := asError(ApplyRangerV2(ctx, clusterAPI, clusterId, rangerConfigId)); err != nil {
+		return fmt.Errorf("failed to apply ranger config (%s) to cluster (%s): %s", rangerConfigId, clusterId, err.Error())
+	}
+
+	d.Set("ranger_config_checksum", checksum)
+	return nil
+}
@@ -0,0 +1,260 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func resourceClusterSchedulingPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterSchedulingPolicyCreate,
+		ReadContext:   resourceClusterSchedulingPolicyRead,
+		UpdateContext: resourceClusterSchedulingPolicyUpdate,
+		DeleteContext: resourceClusterSchedulingPolicyDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"policy_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"time_zone": {
+				Type:         schema.TypeString,
+				Description:  "IANA Time-Zone",
+				Optional:     true,
+				Default:      "UTC",
+				ValidateFunc: common.ValidateSchedulingPolicyTimeZone,
+			},
+			"active_days": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 7,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(cluster.WeekDays, false),
+				},
+			},
+			"resume_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: common.ValidateSchedulingPolicyDateTime,
+			},
+			"suspend_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: common.ValidateSchedulingPolicyDateTime,
+			},
+			"enable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceClusterSchedulingPolicyImport,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			return checkSchedulingPolicyTime(d.Get("policy_name").(string), d.Get("resume_at").(string), d.Get("suspend_at").(string))
+		},
+	}
+}
+
+// schedulingPolicyParamKeys are the attributes of celerdatabyoc_cluster_scheduling_policy that are shared with the `scheduling_policy` block of the cluster resources.
+var schedulingPolicyParamKeys = []string{
+	"policy_name",
+	"description",
+	"time_zone",
+	"active_days",
+	"resume_at",
+	"suspend_at",
+	"enable",
+}
+
+// filterSchedulingPolicies drops the policies that are not declared in `scheduling_policy` blocks when
+// `ignore_unmanaged_scheduling_policies` is set, so the cluster resource leaves them alone.
+func filterSchedulingPolicies(d *schema.ResourceData, policies []map[string]interface{}, policyExtraInfo map[string]string) ([]map[string]interface{}, map[string]string) {
+	if !d.Get("ignore_unmanaged_scheduling_policies").(bool) {
+		return policies, policyExtraInfo
+	}
+
+	managedPolicies := make(map[string]bool)
+	for _, v := range d.Get("scheduling_policy").([]interface{}) {
+		managedPolicies[v.(map[string]interface{})["policy_name"].(string)] = true
+	}
+
+	retPolicies := make([]map[string]interface{}, 0)
+	retExtraInfo := make(map[string]string)
+	for _, item := range policies {
+		policyName := item["policy_name"].(string)
+		if !managedPolicies[policyName] {
+			log.Printf("[DEBUG] ignore unmanaged scheduling policy[%s] of cluster (%s)", policyName, d.Id())
+			continue
+		}
+		retPolicies = append(retPolicies, item)
+		retExtraInfo[policyName] = policyExtraInfo[policyName]
+	}
+	return retPolicies, retExtraInfo
+}
+
+func resourceClusterSchedulingPolicyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return nil, fmt.Errorf("invalid import id %q, expected <cluster_id>/<policy_name>", d.Id())
+	}
+	clusterId, policyName := parts[0], parts[1]
+
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	_, policyExtraInfo, err := ListClusterSchedulingPolicy(ctx, clusterAPI, clusterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduling policies of cluster (%s): %s", clusterId, err.Error())
+	}
+
+	policyId, ok := policyExtraInfo[policyName]
+	if !ok {
+		return nil, fmt.Errorf("scheduling policy[%s] not found in cluster (%s)", policyName, clusterId)
+	}
+
+	d.SetId(policyId)
+	d.Set("cluster_id", clusterId)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceClusterSchedulingPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+	policyName := d.Get("policy_name").(string)
+
+	checkResp, err := clusterAPI.IsSchedulePolicyNameExist(ctx, &cluster.CheckClusterSchedulePolicyReq{
+		ClusterId:  clusterId,
+		PolicyName: policyName,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to check scheduling policy name of cluster (%s): %s", clusterId, err.Error()))
+	}
+	if checkResp.Exist {
+		return diag.FromErr(fmt.Errorf("scheduling policy[%s] already exists in cluster (%s), import it with id `%s/%s`", policyName, clusterId, clusterId, policyName))
+	}
+
+	policyId, err := SaveClusterSchedulingPolicy(ctx, clusterAPI, clusterId, schedulingPolicyParamMap(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to add scheduling policy[%s] to cluster (%s): %s", policyName, clusterId, err.Error()))
+	}
+	d.SetId(policyId)
+
+	return resourceClusterSchedulingPolicyRead(ctx, d, m)
+}
+
+func resourceClusterSchedulingPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+	policyId := d.Id()
+
+	resp, err := clusterAPI.ListClusterSchedulePolicy(ctx, &cluster.ListClusterSchedulePolicyReq{ClusterId: clusterId})
+	if err != nil {
+		if !d.IsNewResource() && status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing scheduling policy (%s) from state", clusterId, policyId)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	var policy *cluster.ClusterSchedulePolicy
+	for _, item := range resp.SchedulePolicies {
+		if item.PolicyId == policyId {
+			policy = item
+			break
+		}
+	}
+	if policy == nil {
+		log.Printf("[WARN] Scheduling policy (%s) not found in cluster (%s), removing from state", policyId, clusterId)
+		d.SetId("")
+		return nil
+	}
+
+	activeDays := make([]string, 0)
+	for _, day := range strings.Split(policy.ActiveDateValue, ",") {
+		if len(strings.TrimSpace(day)) > 0 {
+			activeDays = append(activeDays, strings.TrimSpace(day))
+		}
+	}
+
+	d.Set("policy_name", policy.PolicyName)
+	d.Set("description", policy.Description)
+	d.Set("time_zone", policy.TimeZone)
+	d.Set("active_days", activeDays)
+	d.Set("resume_at", policy.ResumeAt)
+	d.Set("suspend_at", policy.SuspendAt)
+	d.Set("enable", policy.State == int32(1))
+	return nil
+}
+
+func resourceClusterSchedulingPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	if d.HasChange("policy_name") {
+		policyName := d.Get("policy_name").(string)
+		checkResp, err := clusterAPI.IsSchedulePolicyNameExist(ctx, &cluster.CheckClusterSchedulePolicyReq{
+			ClusterId:  clusterId,
+			PolicyName: policyName,
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to check scheduling policy name of cluster (%s): %s", clusterId, err.Error()))
+		}
+		if checkResp.Exist {
+			return diag.FromErr(fmt.Errorf("scheduling policy[%s] already exists in cluster (%s)", policyName, clusterId))
+		}
+	}
+
+	err := ModifyClusterSchedulingPolicy(ctx, clusterAPI, clusterId, d.Id(), schedulingPolicyParamMap(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to modify scheduling policy (%s) of cluster (%s): %s", d.Id(), clusterId, err.Error()))
+	}
+
+	return resourceClusterSchedulingPolicyRead(ctx, d, m)
+}
+
+func resourceClusterSchedulingPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	err := DeleteClusterSchedulingPolicy(ctx, clusterAPI, clusterId, d.Id())
+	if err != nil && status.Code(err) != codes.NotFound {
+		return diag.FromErr(fmt.Errorf("failed to delete scheduling policy (%s) of cluster (%s): %s", d.Id(), clusterId, err.Error()))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func schedulingPolicyParamMap(d *schema.ResourceData) map[string]interface{} {
+	m := make(map[string]interface{}, len(schedulingPolicyParamKeys))
+	for _, k := range schedulingPolicyParamKeys {
+		m[k] = d.Get(k)
+	}
+	return m
+}
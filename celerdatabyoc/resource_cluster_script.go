@@ -0,0 +1,256 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptsStartTimeout is how long runScripts waits for the cluster to start running the scripts. The cluster
+// state is polled every few seconds, so scripts that finish between two polls are only noticed when the wait
+// times out, which is why it's kept short.
+const scriptsStartTimeout = 1 * time.Minute
+
+// celerdatabyoc_cluster_script runs a script stored in S3 on the nodes of a cluster, and runs it again
+// whenever the script, its hash or the local copy of the script changes.
+//
+// The CelerData API only runs scripts from S3 on all the nodes of a cluster and reports neither the nodes
+// nor the outcome of each of them, so the resource can't upload inline content, target node types or
+// warehouses, or report per-node results; the output of each node is in `logs_dir`. The deployment scripts
+// (`UpdateDeploymentScripts`) are owned by the `init_scripts` of the cluster resources.
+func resourceClusterScript() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterScriptCreate,
+		ReadContext:   resourceClusterScriptRead,
+		UpdateContext: resourceClusterScriptUpdate,
+		DeleteContext: resourceClusterScriptDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"script_path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateS3Path,
+			},
+			"logs_dir": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateS3Path,
+			},
+			"source_hash": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The hash of the script, such as `filemd5(\"init.sh\")`. The script runs again when it changes.",
+			},
+			"source_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The local copy of the script that is uploaded to `script_path`. The script runs again when its content changes.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"source_file_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"run_scripts_parallel": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_run_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if !d.NewValueKnown("source_file") {
+				return d.SetNewComputed("source_file_sha256")
+			}
+			sourceFile := d.Get("source_file").(string)
+			if len(sourceFile) == 0 {
+				if len(d.Get("source_file_sha256").(string)) > 0 {
+					return d.SetNew("source_file_sha256", "")
+				}
+				return nil
+			}
+
+			checksum, err := fileSha256(sourceFile)
+			if err != nil {
+				return cty.GetAttrPath("source_file").NewError(err)
+			}
+			if checksum != d.Get("source_file_sha256").(string) {
+				return d.SetNew("source_file_sha256", checksum)
+			}
+			return nil
+		},
+	}
+}
+
+// fileSha256 returns the SHA-256 checksum of the content of a local file.
+func fileSha256(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %s", path, err.Error())
+	}
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func resourceClusterScriptCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterId := d.Get("cluster_id").(string)
+	d.SetId(fmt.Sprintf("%s/%s", clusterId, strconv.FormatInt(time.Now().UnixNano(), 10)))
+
+	// A failed run keeps the resource tainted, so the next apply runs the script again.
+	return runClusterScript(ctx, m, d)
+}
+
+func resourceClusterScriptRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	// The outcome of the last run doesn't change, only check that the cluster still exists.
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing script (%s) from state", clusterId, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if resp.Cluster.ClusterState == cluster.ClusterStateReleased {
+		log.Printf("[WARN] Cluster (%s) is released, removing script (%s) from state", clusterId, d.Id())
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceClusterScriptUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChanges("script_path", "logs_dir", "source_hash", "source_file_sha256") {
+		return nil
+	}
+
+	// Keep the old arguments in the state when the run fails, so the next apply runs the script again.
+	d.Partial(true)
+	if diags := runClusterScript(ctx, m, d); diags.HasError() {
+		return diags
+	}
+	d.Partial(false)
+	return nil
+}
+
+func resourceClusterScriptDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// A script that has run can't be undone, removing it only forgets its outcome.
+	d.SetId("")
+	return nil
+}
+
+func runClusterScript(ctx context.Context, m interface{}, d *schema.ResourceData) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+	logsDir := d.Get("logs_dir").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, timeoutFromContext(ctx)); diags != nil {
+		return diags
+	}
+
+	d.Set("last_run_at", time.Now().UTC().Format(time.RFC3339))
+	err := runScripts(ctx, clusterAPI, clusterId, []*cluster.Script{
+		{
+			ScriptPath: d.Get("script_path").(string),
+			LogsDir:    logsDir,
+		},
+	}, d.Get("run_scripts_parallel").(bool))
+	if err != nil {
+		log.Printf("[ERROR] run script of cluster (%s) failed, err:%+v", clusterId, err)
+		d.Set("status", CLUSTER_ACTION_STATUS_FAILED)
+		d.Set("message", err.Error())
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to run script on cluster (%s)", clusterId),
+				Detail:   fmt.Sprintf("%s\n\nThe output of the script on each node is written to %s.", err.Error(), logsDir),
+			},
+		}
+	}
+
+	d.Set("status", CLUSTER_ACTION_STATUS_SUCCEEDED)
+	d.Set("message", "")
+	return nil
+}
+
+// runScripts runs the scripts on the nodes of the cluster. RunScripts doesn't return an infra action, so it
+// waits for the cluster to leave the running state, which means the run started, before waiting for the
+// cluster to be stable again. Otherwise the wait could return before the scripts even started.
+func runScripts(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, scripts []*cluster.Script, parallel bool) error {
+	err := clusterAPI.RunScripts(ctx, &cluster.RunScriptsReq{
+		ClusterId:          clusterId,
+		Scripts:            scripts,
+		RunScriptsParallel: parallel,
+	})
+	if err != nil {
+		return fmt.Errorf("run script failed, err:%s", err.Error())
+	}
+
+	_, err = WaitClusterStateChangeComplete(ctx, &waitStateReq{
+		clusterAPI:    clusterAPI,
+		clusterID:     clusterId,
+		timeout:       scriptsStartTimeout,
+		pendingStates: []string{string(cluster.ClusterStateRunning)},
+		targetStates: []string{
+			string(cluster.ClusterStateUpdating),
+			string(cluster.ClusterStateScaling),
+			string(cluster.ClusterStateDeploying),
+			string(cluster.ClusterStateAbnormal),
+		},
+	})
+	if err != nil {
+		var timeoutErr *retry.TimeoutError
+		if !errors.As(err, &timeoutErr) {
+			return fmt.Errorf("waiting for the scripts of cluster (%s) to start: %s", clusterId, err.Error())
+		}
+		log.Printf("[WARN] cluster (%s) is still running %s after the scripts were submitted, they have finished already or never started", clusterId, scriptsStartTimeout)
+	}
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, timeoutFromContext(ctx)); diags != nil {
+		return asError(diags)
+	}
+	return nil
+}
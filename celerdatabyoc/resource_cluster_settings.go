@@ -0,0 +1,305 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func resourceClusterSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterSettingsCreate,
+		ReadContext:   resourceClusterSettingsRead,
+		UpdateContext: resourceClusterSettingsUpdate,
+		DeleteContext: resourceClusterSettingsDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"termination_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"arrow_flight": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"idle_suspend_interval": {
+				Type:        schema.TypeInt,
+				Description: "Specifies the amount of time (in minutes) during which a cluster can stay idle. After the specified time period elapses, the cluster will be automatically suspended. 0 disables it.",
+				Optional:    true,
+				Computed:    true,
+				ValidateFunc: validation.Any(
+					validation.IntInSlice([]int{0}),
+					validation.IntBetween(15, 999999),
+				),
+			},
+			"table_name_case_insensitive": {
+				Type:        schema.TypeBool,
+				Description: "Whether table names are case-insensitive. It's determined when the cluster is created and cannot be modified.",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if len(d.Id()) > 0 && d.HasChange("table_name_case_insensitive") {
+				return cty.GetAttrPath("table_name_case_insensitive").NewErrorf("`table_name_case_insensitive` of cluster (%s) cannot be modified after the cluster is created", d.Id())
+			}
+			return nil
+		},
+	}
+}
+
+// The attributes that replace each cluster resource, computed once because building the schema of a cluster
+// resource on every plan is expensive.
+var (
+	classicClusterForceNewKeys   []string
+	elasticClusterForceNewKeys   []string
+	elasticClusterV2ForceNewKeys []string
+)
+
+func init() {
+	classicClusterForceNewKeys = forceNewKeys(resourceClassicCluster().Schema, "")
+	elasticClusterForceNewKeys = forceNewKeys(resourceElasticCluster().Schema, "")
+	elasticClusterV2ForceNewKeys = forceNewKeys(resourceElasticClusterV2().Schema, "")
+}
+
+// forceNewKeys returns the attributes of s that force a new resource, including the nested ones. The elements
+// of a block are addressed as `<block>.*.<attribute>`.
+func forceNewKeys(s map[string]*schema.Schema, prefix string) []string {
+	keys := make([]string, 0)
+	for k, v := range s {
+		if v.ForceNew {
+			keys = append(keys, prefix+k)
+		}
+		if r, ok := v.Elem.(*schema.Resource); ok {
+			keys = append(keys, forceNewKeys(r.Schema, prefix+k+".*.")...)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isForceNewKey reports whether changing the flattened key, for example `custom_ami.0.ami`, changes one of the
+// attributes of forceNewKeys or one of their elements.
+func isForceNewKey(key string, forceNewKeys []string) bool {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		if _, err := strconv.Atoi(part); err == nil {
+			parts[i] = "*"
+		}
+	}
+	for i := len(parts); i > 0; i-- {
+		if cluster.Contains(forceNewKeys, strings.Join(parts[:i], ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTerminationProtectionOnReplace returns a plan-time error when a cluster with termination protection enabled
+// would be replaced by changing one of forceNewKeys. Keys that force a new resource only under some conditions, in
+// CustomizeDiff, must be added by the caller when they do.
+//
+// The plugin SDK doesn't run CustomizeDiff when a resource is planned to be destroyed, so a plain `terraform destroy`
+// or the removal of the resource from the configuration is not stopped at plan time. Those are stopped at apply
+// time by checkTerminationProtection, before the cluster is touched.
+func checkTerminationProtectionOnReplace(d *schema.ResourceDiff, forceNewKeys []string) error {
+	if len(d.Id()) == 0 {
+		return nil
+	}
+	if o, _ := d.GetChange("enabled_termination_protection"); !o.(bool) {
+		return nil
+	}
+
+	changedKeys := d.GetChangedKeysPrefix("")
+	sort.Strings(changedKeys)
+	for _, k := range changedKeys {
+		k = strings.TrimSuffix(strings.TrimSuffix(k, ".#"), ".%")
+		if isForceNewKey(k, forceNewKeys) && d.HasChange(k) {
+			return cty.GetAttrPath(strings.SplitN(k, ".", 2)[0]).NewErrorf("changing `%s` replaces cluster (%s), which has termination protection enabled. Disable termination protection first", k, d.Id())
+		}
+	}
+	return nil
+}
+
+// checkTerminationProtection stops the release of a cluster with termination protection enabled
+// before anything is changed.
+func checkTerminationProtection(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string) diag.Diagnostics {
+	resp, err := clusterAPI.GetClusterTerminationProtection(ctx, &cluster.GetClusterTerminationProtectionReq{ClusterId: clusterId})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("failed to get termination protection of cluster (%s): %s", clusterId, err.Error()))
+	}
+
+	if resp.Enabled {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Cluster has termination protection enabled",
+				Detail: fmt.Sprintf("Cluster (%s) cannot be destroyed while termination protection is enabled. "+
+					"Set `enabled_termination_protection` of the cluster, or `termination_protection` of its celerdatabyoc_cluster_settings resource, to false and apply it first.", clusterId),
+			},
+		}
+	}
+	return nil
+}
+
+func resourceClusterSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	rawConfig := d.GetRawConfig()
+	if !rawConfig.GetAttr("table_name_case_insensitive").IsNull() {
+		resp, err := clusterAPI.GetClusterTableNameCaseInsensitive(ctx, &cluster.GetClusterTableNameCaseInsensitiveReq{ClusterId: clusterId})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to get table_name_case_insensitive of cluster (%s): %s", clusterId, err.Error()))
+		}
+		if resp.Enabled != d.Get("table_name_case_insensitive").(bool) {
+			return diag.FromErr(fmt.Errorf("`table_name_case_insensitive` of cluster (%s) is %t and cannot be modified after the cluster is created", clusterId, resp.Enabled))
+		}
+	}
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	d.SetId(clusterId)
+	if err := applyClusterSettings(ctx, clusterAPI, d, func(key string) bool {
+		return !rawConfig.GetAttr(key).IsNull()
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClusterSettingsRead(ctx, d, m)
+}
+
+func resourceClusterSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Id()
+
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		if !d.IsNewResource() && status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing settings from state", clusterId)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	terminationProtection, err := clusterAPI.GetClusterTerminationProtection(ctx, &cluster.GetClusterTerminationProtectionReq{ClusterId: clusterId})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get termination protection of cluster (%s): %s", clusterId, err.Error()))
+	}
+
+	arrowFlight, err := clusterAPI.GetClusterArrowFlight(ctx, &cluster.GetClusterArrowFlightReq{ClusterId: clusterId})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get arrow flight of cluster (%s): %s", clusterId, err.Error()))
+	}
+
+	tableNameCaseInsensitive, err := clusterAPI.GetClusterTableNameCaseInsensitive(ctx, &cluster.GetClusterTableNameCaseInsensitiveReq{ClusterId: clusterId})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get table_name_case_insensitive of cluster (%s): %s", clusterId, err.Error()))
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("termination_protection", terminationProtection.Enabled)
+	d.Set("arrow_flight", arrowFlight.Enabled)
+	d.Set("idle_suspend_interval", resp.Cluster.IdleSuspendInterval)
+	d.Set("table_name_case_insensitive", tableNameCaseInsensitive.Enabled)
+	return nil
+}
+
+func resourceClusterSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+
+	unlock := lockCluster(d.Id())
+	defer unlock()
+
+	if err := applyClusterSettings(ctx, clusterAPI, d, d.HasChange); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClusterSettingsRead(ctx, d, m)
+}
+
+func resourceClusterSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] remove settings of cluster (%s) from state, the settings of the cluster are kept", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// applyClusterSettings applies the settings selected by changed.
+func applyClusterSettings(ctx context.Context, clusterAPI cluster.IClusterAPI, d *schema.ResourceData, changed func(key string) bool) error {
+	clusterId := d.Id()
+
+	if changed("termination_protection") {
+		err := clusterAPI.SetClusterTerminationProtection(ctx, clusterId, &cluster.SetClusterTerminationProtectionReq{
+			Enabled: d.Get("termination_protection").(bool),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set termination protection of cluster (%s): %s", clusterId, err.Error())
+		}
+	}
+
+	if changed("arrow_flight") {
+		resp, err := clusterAPI.SetClusterArrowFlight(ctx, &cluster.SetClusterArrowFlightReq{
+			ClusterId: clusterId,
+			Enabled:   d.Get("arrow_flight").(bool),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set arrow flight of cluster (%s): %s", clusterId, err.Error())
+		}
+		if len(resp.ActionID) > 0 {
+			if err := waitInfraAction(ctx, clusterAPI, clusterId, resp.ActionID); err != nil {
+				return fmt.Errorf("failed to set arrow flight of cluster (%s): %s", clusterId, err.Error())
+			}
+		}
+	}
+
+	if changed("idle_suspend_interval") {
+		o, n := d.GetChange("idle_suspend_interval")
+		v := n.(int)
+		enable := v > 0
+		if !enable {
+			v = o.(int)
+		}
+		err := clusterAPI.UpsertClusterIdleConfig(ctx, &cluster.UpsertClusterIdleConfigReq{
+			ClusterId:  clusterId,
+			IntervalMs: uint64(v * 60 * 1000),
+			Enable:     enable,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set idle suspend interval of cluster (%s): %s", clusterId, err.Error())
+		}
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/common"
 )
 
 func resourceClusterSSLCert() *schema.Resource {
@@ -45,6 +46,12 @@ func resourceClusterSSLCert() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Update: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
+		},
 	}
 }
 
@@ -47,14 +47,23 @@ func resourceClusterUser() *schema.Resource {
 			},
 			"user_password": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
 				Sensitive:        true,
 				ValidateDiagFunc: common.ValidatePassword(),
 			},
+			"user_password_wo":         writeOnlySchema("user_password", "The password of the user to be created.", common.ValidatePassword()),
+			"user_password_wo_version": writeOnlyVersionSchema("user_password"),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		ValidateRawResourceConfigFuncs: preferWriteOnly("user_password"),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Update: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
+		},
 	}
 }
 
@@ -131,7 +140,7 @@ func createDatabaseUser(ctx context.Context, data *schema.ResourceData, i interf
 		ClusterId: clusterId,
 		NewUserInfo: cluster.DatabaseUserInfo{
 			UserName: data.Get("user_name").(string),
-			Password: data.Get("user_password").(string),
+			Password: getSecret(data, "user_password"),
 		},
 		LoginUserInfo: cluster.DatabaseUserInfo{
 			UserName: data.Get("login_user").(string),
@@ -263,7 +272,7 @@ func updateDatabaseUser(ctx context.Context, data *schema.ResourceData, i interf
 		ClusterId: clusterId,
 		UserInfo: cluster.DatabaseUserInfo{
 			UserName: data.Get("user_name").(string),
-			Password: data.Get("user_password").(string),
+			Password: getSecret(data, "user_password"),
 		},
 		LoginUserInfo: cluster.DatabaseUserInfo{
 			UserName: data.Get("login_user").(string),
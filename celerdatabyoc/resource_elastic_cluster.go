@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log"
 	"regexp"
-	"time"
 
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
@@ -14,6 +13,7 @@ import (
 	"terraform-provider-celerdatabyoc/common"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -153,10 +153,11 @@ func resourceElasticCluster() *schema.Resource {
 			},
 			"default_admin_password": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
 				Sensitive:        true,
 				ValidateDiagFunc: common.ValidatePassword(),
 			},
+			"default_admin_password_wo": writeOnlySchema("default_admin_password", "The initial password of the cluster `admin` user.", common.ValidatePassword()),
 			"data_credential_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -384,27 +385,40 @@ func resourceElasticCluster() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"ignore_unmanaged_scheduling_policies": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to ignore the scheduling policies that are not declared in `scheduling_policy` blocks, such as the ones managed by `celerdatabyoc_cluster_scheduling_policy` resources. When it's false, such policies are deleted.",
+			},
 			"ranger_config_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
 		},
+		ValidateRawResourceConfigFuncs: preferWriteOnly("default_admin_password"),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
 			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
 		},
 		CustomizeDiff: customizeElDiff,
 	}
 }
 
 func customizeElDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := checkTerminationProtectionOnReplace(d, elasticClusterForceNewKeys); err != nil {
+		return err
+	}
+
 	c := m.(*client.CelerdataClient)
-	clusterAPI := cluster.NewClustersAPI(c)
 	networkAPI := network.NewNetworkAPI(c)
+	vmCatalog := cluster.SharedVmCatalog(c)
 
 	clusterId := d.Id()
 	csp := d.Get("csp").(string)
@@ -412,21 +426,12 @@ func customizeElDiff(ctx context.Context, d *schema.ResourceDiff, m interface{})
 	isNewResource := d.Id() == ""
 
 	n := d.Get("coordinator_node_size")
-	newCoordinatorVmInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-		Csp:         csp,
-		Region:      region,
-		ProcessType: string(cluster.ClusterModuleTypeFE),
-		VmCate:      n.(string),
-	})
+	newCoordinatorVmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeFE), n.(string), cty.GetAttrPath("coordinator_node_size"))
 	if err != nil {
-		log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, n.(string), err)
-		return fmt.Errorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, n.(string), err.Error())
-	}
-	if newCoordinatorVmInfoResp.VmInfo == nil {
-		return fmt.Errorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, n.(string))
+		return err
 	}
 
-	feArch := newCoordinatorVmInfoResp.VmInfo.Arch
+	feArch := newCoordinatorVmInfo.Arch
 
 	if len(d.Get("network_id").(string)) > 0 {
 		netResp, err := networkAPI.GetNetwork(ctx, d.Get("network_id").(string))
@@ -442,21 +447,12 @@ func customizeElDiff(ctx context.Context, d *schema.ResourceDiff, m interface{})
 	if d.HasChange("coordinator_node_size") {
 		if len(clusterId) > 0 {
 			o, _ := d.GetChange("coordinator_node_size")
-			oldVmInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-				Csp:         csp,
-				Region:      region,
-				ProcessType: string(cluster.ClusterModuleTypeFE),
-				VmCate:      o.(string),
-			})
+			oldVmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeFE), o.(string), cty.GetAttrPath("coordinator_node_size"))
 			if err != nil {
-				log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, o.(string), err)
-				return fmt.Errorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, o.(string), err.Error())
-			}
-			if oldVmInfoResp.VmInfo == nil {
-				return fmt.Errorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, o.(string))
+				return err
 			}
-			if feArch != oldVmInfoResp.VmInfo.Arch {
-				return fmt.Errorf("vm architecture can not be changed, csp:%s region:%s oldVmCate:%s  newVmCate:%s", csp, region, o.(string), n.(string))
+			if feArch != oldVmInfo.Arch {
+				return cty.GetAttrPath("coordinator_node_size").NewErrorf("vm architecture can not be changed, csp:%s region:%s oldVmCate:%s  newVmCate:%s", csp, region, o.(string), n.(string))
 			}
 		}
 	}
@@ -477,45 +473,34 @@ func customizeElDiff(ctx context.Context, d *schema.ResourceDiff, m interface{})
 		oldVolumeSize, newVolumeSize := oldVolumeConfig["vol_size"].(int), newVolumeConfig["vol_size"].(int)
 
 		if newVolumeSize < oldVolumeSize {
-			return fmt.Errorf("the coordinator node `vol_size` does not support decrease")
+			return cty.GetAttrPath("coordinator_node_volume_config").IndexInt(0).GetAttr("vol_size").NewErrorf("the coordinator node `vol_size` does not support decrease")
 		}
 	}
 
-	if !newCoordinatorVmInfoResp.VmInfo.IsInstanceStore {
+	if !newCoordinatorVmInfo.IsInstanceStore {
 		if v, ok := d.GetOk("coordinator_node_volume_config"); ok {
-			nodeType := "Coordinator node"
-			volumeCate := newCoordinatorVmInfoResp.VmInfo.VmVolumeInfos[0].VolumeCate
-			volumeConfig := v.([]interface{})[0].(map[string]interface{})
 			err = VolumeParamVerify(ctx, &VolumeParamVerifyReq{
-				ClusterAPI:   clusterAPI,
-				VolumeCate:   volumeCate,
-				VolumeConfig: volumeConfig,
+				VmCatalog:    vmCatalog,
+				NodeType:     "Coordinator node",
+				VolumeCate:   newCoordinatorVmInfo.VmVolumeInfos[0].VolumeCate,
+				VolumeConfig: v.([]interface{})[0].(map[string]interface{}),
+				Path:         cty.GetAttrPath("coordinator_node_volume_config").IndexInt(0),
 			})
 			if err != nil {
-				log.Printf("[ERROR] verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-				return fmt.Errorf("verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
+				return err
 			}
 		}
 	}
 
 	newCn := d.Get("compute_node_size")
-	newCnVmInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-		Csp:         csp,
-		Region:      region,
-		ProcessType: string(cluster.ClusterModuleTypeBE),
-		VmCate:      newCn.(string),
-	})
+	newCnVmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeBE), newCn.(string), cty.GetAttrPath("compute_node_size"))
 	if err != nil {
-		log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, newCn.(string), err)
-		return fmt.Errorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, newCn.(string), err.Error())
-	}
-	if newCnVmInfoResp.VmInfo == nil {
-		return fmt.Errorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, newCn.(string))
+		return err
 	}
 
 	if d.HasChange("compute_node_size") {
-		if feArch != newCnVmInfoResp.VmInfo.Arch {
-			return fmt.Errorf("compute node architecture should be same with coordinator node, expect:%s but found:%s", feArch, newCnVmInfoResp.VmInfo.Arch)
+		if feArch != newCnVmInfo.Arch {
+			return cty.GetAttrPath("compute_node_size").NewErrorf("compute node architecture should be same with coordinator node, expect:%s but found:%s", feArch, newCnVmInfo.Arch)
 		}
 
 		if len(clusterId) > 0 {
@@ -524,8 +509,8 @@ func customizeElDiff(ctx context.Context, d *schema.ResourceDiff, m interface{})
 			if !isInstanceStore {
 				expectStr = "nonlocal disk vm instance type"
 			}
-			if newCnVmInfoResp.VmInfo.IsInstanceStore != isInstanceStore {
-				return fmt.Errorf("the disk type of the compute node must be the same as the previous disk type, expect:%s", expectStr)
+			if newCnVmInfo.IsInstanceStore != isInstanceStore {
+				return cty.GetAttrPath("compute_node_size").NewErrorf("the disk type of the compute node must be the same as the previous disk type, expect:%s", expectStr)
 			}
 		}
 	}
@@ -543,34 +528,33 @@ func customizeElDiff(ctx context.Context, d *schema.ResourceDiff, m interface{})
 			newVolumeConfig = n.([]interface{})[0].(map[string]interface{})
 		}
 
+		volumePath := cty.GetAttrPath("compute_node_volume_config").IndexInt(0)
 		newVolumeNumber := newVolumeConfig["vol_number"].(int)
-		if newVolumeNumber > int(newCnVmInfoResp.VmInfo.MaxDataDiskCount) {
-			return fmt.Errorf("the maximum allowed `vol_number` for this VM type is: %d", newCnVmInfoResp.VmInfo.MaxDataDiskCount)
+		if newVolumeNumber > int(newCnVmInfo.MaxDataDiskCount) {
+			return volumePath.GetAttr("vol_number").NewErrorf("the maximum allowed `vol_number` for this VM type is: %d", newCnVmInfo.MaxDataDiskCount)
 		}
 		if newVolumeNumber < 1 {
-			return fmt.Errorf("the minimum allowed `vol_number` is: 1")
+			return volumePath.GetAttr("vol_number").NewErrorf("the minimum allowed `vol_number` is: 1")
 		}
 
 		oldVolumeSize, newVolumeSize := oldVolumeConfig["vol_size"].(int), newVolumeConfig["vol_size"].(int)
 
 		if newVolumeSize < oldVolumeSize {
-			return fmt.Errorf("the compute node `vol_size` does not support decrease")
+			return volumePath.GetAttr("vol_size").NewErrorf("the compute node `vol_size` does not support decrease")
 		}
 	}
 
-	if !newCnVmInfoResp.VmInfo.IsInstanceStore {
+	if !newCnVmInfo.IsInstanceStore {
 		if v, ok := d.GetOk("compute_node_volume_config"); ok {
-			nodeType := "Compute node"
-			volumeCate := newCnVmInfoResp.VmInfo.VmVolumeInfos[0].VolumeCate
-			volumeConfig := v.([]interface{})[0].(map[string]interface{})
 			err = VolumeParamVerify(ctx, &VolumeParamVerifyReq{
-				ClusterAPI:   clusterAPI,
-				VolumeCate:   volumeCate,
-				VolumeConfig: volumeConfig,
+				VmCatalog:    vmCatalog,
+				NodeType:     "Compute node",
+				VolumeCate:   newCnVmInfo.VmVolumeInfos[0].VolumeCate,
+				VolumeConfig: v.([]interface{})[0].(map[string]interface{}),
+				Path:         cty.GetAttrPath("compute_node_volume_config").IndexInt(0),
 			})
 			if err != nil {
-				log.Printf("[ERROR] verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-				return fmt.Errorf("verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
+				return err
 			}
 		}
 	}
@@ -601,7 +585,7 @@ func resourceElasticClusterCreate(ctx context.Context, d *schema.ResourceData, m
 		Csp:                          d.Get("csp").(string),
 		Region:                       d.Get("region").(string),
 		ClusterType:                  cluster.ClusterTypeElasic,
-		Password:                     d.Get("default_admin_password").(string),
+		Password:                     getSecret(d, "default_admin_password"),
 		SslConnEnable:                true,
 		NetIfaceId:                   d.Get("network_id").(string),
 		DeployCredlId:                d.Get("deployment_credential_id").(string),
@@ -711,7 +695,7 @@ func resourceElasticClusterCreate(ctx context.Context, d *schema.ResourceData, m
 		clusterAPI: clusterAPI,
 		clusterID:  resp.ClusterID,
 		actionID:   resp.ActionID,
-		timeout:    common.DeployOrScaleClusterTimeout,
+		timeout:    d.Timeout(schema.TimeoutCreate),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -830,7 +814,7 @@ func resourceElasticClusterCreate(ctx context.Context, d *schema.ResourceData, m
 		policies := v.([]interface{})
 		for _, item := range policies {
 			m := item.(map[string]interface{})
-			err := SaveClusterSchedulingPolicy(ctx, clusterAPI, clusterId, m)
+			_, err := SaveClusterSchedulingPolicy(ctx, clusterAPI, clusterId, m)
 			if err != nil {
 				return diag.Diagnostics{
 					diag.Diagnostic{
@@ -864,7 +848,7 @@ func resourceElasticClusterRead(ctx context.Context, d *schema.ResourceData, m i
 	stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterID,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutRead),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -909,6 +893,7 @@ func resourceElasticClusterRead(ctx context.Context, d *schema.ResourceData, m i
 		log.Printf("[ERROR] query cluster coordinator node config failed, err:%+v", err)
 		return diag.FromErr(err)
 	}
+	coordinatorNodeConfigsResp.Configs = withoutLdapConfigs(coordinatorNodeConfigsResp.Configs, d.Get("coordinator_node_configs"))
 
 	computeNodeConfigsResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
 		ClusterID:  clusterID,
@@ -938,6 +923,7 @@ func resourceElasticClusterRead(ctx context.Context, d *schema.ResourceData, m i
 		log.Printf("[ERROR] list cluster schedule policy failed,clusterId:%s err:%+v", clusterID, err)
 		return diag.FromErr(err)
 	}
+	policies, policyExtraInfo = filterSchedulingPolicies(d, policies, policyExtraInfo)
 
 	terminationProtection, err := clusterAPI.GetClusterTerminationProtection(ctx, &cluster.GetClusterTerminationProtectionReq{ClusterId: clusterID})
 	if err != nil {
@@ -991,7 +977,8 @@ func resourceElasticClusterRead(ctx context.Context, d *schema.ResourceData, m i
 		d.Set("ranger_certs_dir", resp.Cluster.RangerCertsDirPath)
 	}
 
-	if len(coordinatorNodeConfigsResp.Configs) > 0 {
+	// The LDAP configs may have been dropped, so the configs are also set when the state has some.
+	if len(coordinatorNodeConfigsResp.Configs) > 0 || len(d.Get("coordinator_node_configs").(map[string]interface{})) > 0 {
 		d.Set("coordinator_node_configs", coordinatorNodeConfigsResp.Configs)
 	}
 
@@ -1048,10 +1035,14 @@ func resourceElasticClusterDelete(ctx context.Context, d *schema.ResourceData, m
 	log.Printf("[DEBUG] resourceElasticClusterDelete cluster id:%s", clusterID)
 	var diags diag.Diagnostics
 
+	if diags := checkTerminationProtection(ctx, clusterAPI, clusterID); diags.HasError() {
+		return diags
+	}
+
 	_, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterID,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutDelete),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -1082,7 +1073,7 @@ func resourceElasticClusterDelete(ctx context.Context, d *schema.ResourceData, m
 		clusterAPI: clusterAPI,
 		actionID:   resp.ActionID,
 		clusterID:  clusterID,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutDelete),
 		pendingStates: []string{
 			string(cluster.ClusterStateReleasing),
 			string(cluster.ClusterStateRunning),
@@ -1123,7 +1114,7 @@ func IsInstanceStore(d *schema.ResourceData) bool {
 func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var immutableFields = []string{"csp", "region", "cluster_name", "default_admin_password", "data_credential_id", "deployment_credential_id", "network_id", "query_port"}
 	for _, f := range immutableFields {
-		if d.HasChange(f) && !d.IsNewResource() {
+		if d.HasChange(f) && !d.IsNewResource() && !movedToWriteOnly(d, f) {
 			return diag.FromErr(fmt.Errorf("the `%s` field is not allowed to be modified", f))
 		}
 	}
@@ -1137,7 +1128,7 @@ func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m
 	stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterID,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutUpdate),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -1305,7 +1296,7 @@ func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m
 			clusterAPI:    clusterAPI,
 			actionID:      resp.ActionId,
 			clusterID:     clusterID,
-			timeout:       common.DeployOrScaleClusterTimeout,
+			timeout:       d.Timeout(schema.TimeoutUpdate),
 			pendingStates: []string{string(cluster.ClusterStateScaling)},
 			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 		})
@@ -1351,7 +1342,7 @@ func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m
 			clusterAPI:    clusterAPI,
 			actionID:      actionID,
 			clusterID:     clusterID,
-			timeout:       common.DeployOrScaleClusterTimeout,
+			timeout:       d.Timeout(schema.TimeoutUpdate),
 			pendingStates: []string{string(cluster.ClusterStateScaling)},
 			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 		})
@@ -1398,7 +1389,7 @@ func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m
 				clusterAPI: clusterAPI,
 				clusterID:  clusterID,
 				actionID:   infraActionId,
-				timeout:    30 * time.Minute,
+				timeout:    d.Timeout(schema.TimeoutUpdate),
 				pendingStates: []string{
 					string(cluster.ClusterInfraActionStatePending),
 					string(cluster.ClusterInfraActionStateOngoing),
@@ -1434,11 +1425,16 @@ func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if d.HasChange("coordinator_node_configs") {
-		configMap := d.Get("coordinator_node_configs").(map[string]interface{})
+		o, n := d.GetChange("coordinator_node_configs")
 		configs := make(map[string]string, 0)
-		for k, v := range configMap {
+		for k, v := range n.(map[string]interface{}) {
 			configs[k] = v.(string)
 		}
+		// The coordinator node configs are replaced as a whole, keep the ones of celerdatabyoc_cluster_ldap.
+		configs, err := mergeClusterLdapConfigs(ctx, clusterAPI, clusterID, configs, o.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		warnDiag := UpsertClusterConfig(ctx, clusterAPI, &cluster.UpsertClusterConfigReq{
 			ClusterID:  clusterID,
 			ConfigType: cluster.CustomConfigTypeFE,
@@ -1465,7 +1461,7 @@ func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m
 			clusterAPI:    clusterAPI,
 			actionID:      resp.ActionId,
 			clusterID:     clusterID,
-			timeout:       common.DeployOrScaleClusterTimeout,
+			timeout:       d.Timeout(schema.TimeoutUpdate),
 			pendingStates: []string{string(cluster.ClusterStateScaling)},
 			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 		})
@@ -1511,7 +1507,7 @@ func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m
 			clusterAPI:    clusterAPI,
 			actionID:      actionID,
 			clusterID:     clusterID,
-			timeout:       common.DeployOrScaleClusterTimeout,
+			timeout:       d.Timeout(schema.TimeoutUpdate),
 			pendingStates: []string{string(cluster.ClusterStateScaling)},
 			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
 		})
@@ -1560,7 +1556,7 @@ func resourceElasticClusterUpdate(ctx context.Context, d *schema.ResourceData, m
 				clusterAPI: clusterAPI,
 				clusterID:  clusterID,
 				actionID:   infraActionId,
-				timeout:    30 * time.Minute,
+				timeout:    d.Timeout(schema.TimeoutUpdate),
 				pendingStates: []string{
 					string(cluster.ClusterInfraActionStatePending),
 					string(cluster.ClusterInfraActionStateOngoing),
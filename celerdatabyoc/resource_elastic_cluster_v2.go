@@ -8,7 +8,6 @@ import (
 	"log"
 	"regexp"
 	"strings"
-	"time"
 
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
@@ -16,6 +15,7 @@ import (
 	"terraform-provider-celerdatabyoc/common"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -29,6 +29,10 @@ const (
 	SPECIFY_AZ             = "specify_az"
 )
 
+// The OS that custom AMIs may declare. The base images listed by the cluster versions endpoint narrow it down to
+// the region and the architecture, the list stays until the endpoint is available on every CelerData deployment.
+var customAmiOsList = []string{"al2023"}
+
 // V2 support multi-warehouse
 func resourceElasticClusterV2() *schema.Resource {
 	return &schema.Resource{
@@ -78,7 +82,7 @@ func resourceElasticClusterV2() *schema.Resource {
 						"vol_size": {
 							Type:             schema.TypeInt,
 							Optional:         true,
-							Default:          150,
+							Default:          cluster.DefaultFeVolSize,
 							ValidateDiagFunc: common.ValidateVolumeSize(),
 						},
 						"iops": {
@@ -111,7 +115,7 @@ func resourceElasticClusterV2() *schema.Resource {
 						"os": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"al2023"}, false),
+							ValidateFunc: validation.StringInSlice(customAmiOsList, false),
 						},
 					},
 				},
@@ -166,7 +170,7 @@ func resourceElasticClusterV2() *schema.Resource {
 										Description: "Specifies the number of disk. The default value is 2.",
 										Type:        schema.TypeInt,
 										Optional:    true,
-										Default:     2,
+										Default:     cluster.DefaultBeVolNumber,
 										ValidateFunc: func(i interface{}, k string) (warnings []string, errors []error) {
 											v, ok := i.(int)
 											if !ok {
@@ -185,7 +189,7 @@ func resourceElasticClusterV2() *schema.Resource {
 										Description:      "Specifies the size of a single disk in GB. The default size for per disk is 100GB.",
 										Type:             schema.TypeInt,
 										Optional:         true,
-										Default:          100,
+										Default:          cluster.DefaultBeVolSize,
 										ValidateDiagFunc: common.ValidateVolumeSize(),
 									},
 									"iops": {
@@ -204,8 +208,9 @@ func resourceElasticClusterV2() *schema.Resource {
 							},
 						},
 						"auto_scaling_policy": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressEquivalentAutoScalingPolicy,
 							ValidateFunc: func(i interface{}, s string) ([]string, []error) {
 								err := ValidateAutoScalingPolicyStr(i.(string))
 								if err != nil {
@@ -228,19 +233,9 @@ func resourceElasticClusterV2() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: func(i interface{}, k string) (warnings []string, errors []error) {
-								whName := i.(string)
-								if len(whName) == 0 {
-									errors = append(errors, fmt.Errorf("%s`s value is invalid. Warehouse name can not be empty", k))
-								} else if whName == DEFAULT_WAREHOUSE_NAME {
-									errors = append(errors, fmt.Errorf("%s`s value is invalid. Normal warehouses can't be named: %s", k, DEFAULT_WAREHOUSE_NAME))
-								} else if strings.Contains(whName, "-") {
-									errors = append(errors, fmt.Errorf("%s`s value is invalid. Warehouse name can contain '-'", k))
-								}
-								return warnings, errors
-							},
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateWarehouseName,
 						},
 						"compute_node_size": {
 							Type:         schema.TypeString,
@@ -281,7 +276,7 @@ func resourceElasticClusterV2() *schema.Resource {
 										Description: "Specifies the number of disk. The default value is 2.",
 										Type:        schema.TypeInt,
 										Optional:    true,
-										Default:     2,
+										Default:     cluster.DefaultBeVolNumber,
 										ValidateFunc: func(i interface{}, k string) (warnings []string, errors []error) {
 											v, ok := i.(int)
 											if !ok {
@@ -338,9 +333,11 @@ func resourceElasticClusterV2() *schema.Resource {
 								return warnings, errors
 							},
 						},
+						"scheduling_policy": warehouseSchedulingPolicySchema(),
 						"auto_scaling_policy": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressEquivalentAutoScalingPolicy,
 							ValidateFunc: func(i interface{}, s string) ([]string, []error) {
 								err := ValidateAutoScalingPolicyStr(i.(string))
 								if err != nil {
@@ -363,6 +360,12 @@ func resourceElasticClusterV2() *schema.Resource {
 					},
 				},
 			},
+			"ignore_unmanaged_warehouses": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to ignore the warehouses that are not declared in `warehouse` blocks, such as the ones managed by `celerdatabyoc_warehouse` resources. When it's false, such warehouses are released.",
+			},
 			"warehouse_external_info": {
 				Type:     schema.TypeMap,
 				Computed: true,
@@ -378,10 +381,11 @@ func resourceElasticClusterV2() *schema.Resource {
 			},
 			"default_admin_password": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
 				Sensitive:        true,
 				ValidateDiagFunc: common.ValidatePassword(),
 			},
+			"default_admin_password_wo": writeOnlySchema("default_admin_password", "The initial password of the cluster `admin` user.", common.ValidatePassword()),
 			"data_credential_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -609,56 +613,81 @@ func resourceElasticClusterV2() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"ignore_unmanaged_scheduling_policies": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to ignore the scheduling policies that are not declared in `scheduling_policy` blocks, such as the ones managed by `celerdatabyoc_cluster_scheduling_policy` resources. When it's false, such policies are deleted.",
+			},
 			"ranger_config_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.StringIsNotWhiteSpace,
 			},
+			"planned_operations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The operations the last planned update performs, in order, with their expected impact such as rolling restarts or downtime.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
+		ValidateRawResourceConfigFuncs: preferWriteOnly("default_admin_password"),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
 			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
 		},
 		CustomizeDiff: customizeEl2Diff,
 	}
 }
 
 func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := checkTerminationProtectionOnReplace(d, elasticClusterV2ForceNewKeys); err != nil {
+		return err
+	}
+
 	c := m.(*client.CelerdataClient)
-	clusterAPI := cluster.NewClustersAPI(c)
 	networkAPI := network.NewNetworkAPI(c)
+	vmCatalog := cluster.SharedVmCatalog(c)
 
 	csp := d.Get("csp").(string)
 	region := d.Get("region").(string)
 	isNewResource := d.Id() == ""
 
 	n := d.Get("coordinator_node_size")
-	newCoordinatorVmInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-		Csp:         csp,
-		Region:      region,
-		ProcessType: string(cluster.ClusterModuleTypeFE),
-		VmCate:      n.(string),
-	})
+	coordinatorVmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeFE), n.(string), cty.GetAttrPath("coordinator_node_size"))
 	if err != nil {
-		log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, n.(string), err)
-		return fmt.Errorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, n.(string), err.Error())
-	}
-	if newCoordinatorVmInfoResp.VmInfo == nil {
-		return fmt.Errorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, n.(string))
+		return err
 	}
 
 	warehouses := make([]interface{}, 0)
 	warehouses = append(warehouses, d.Get("default_warehouse").([]interface{})[0])
 	warehouses = append(warehouses, d.Get("warehouse").([]interface{})...)
+	warehousePath := func(i int) cty.Path {
+		if i == 0 {
+			return cty.GetAttrPath("default_warehouse").IndexInt(0)
+		}
+		return cty.GetAttrPath("warehouse").IndexInt(i - 1)
+	}
+
+	for i, v := range warehouses[1:] {
+		if err := checkWarehouseSchedulingPolicies(warehousePath(i+1), v.(map[string]interface{})["scheduling_policy"].([]interface{})); err != nil {
+			return err
+		}
+	}
+
+	multiAz := false
 
 	if len(d.Get("network_id").(string)) > 0 {
 		netResp, err := networkAPI.GetNetwork(ctx, d.Get("network_id").(string))
 		if err != nil {
 			return err
 		}
+		multiAz = netResp.Network.MultiAz
 
 		coordinatorNodeCount := d.Get("coordinator_node_count").(int)
 		if d.HasChange("coordinator_node_count") {
@@ -668,50 +697,58 @@ func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}
 
 		if netResp.Network.MultiAz {
 			if coordinatorNodeCount < 3 {
-				return errors.New("in multi-AZ deployment mode, the number of coordinator nodes should be greater than or equal to 3")
+				return cty.GetAttrPath("coordinator_node_count").NewErrorf("in multi-AZ deployment mode, the number of coordinator nodes should be greater than or equal to 3")
 			}
-			for _, v := range warehouses {
+			for i, v := range warehouses {
 				vMap := v.(map[string]interface{})
 				if len(vMap["distribution_policy"].(string)) == 0 {
-					return errors.New("in multi-AZ deployment mode, the distribution_policy parameter of warehouse can not be empty")
+					return warehousePath(i).GetAttr("distribution_policy").NewErrorf("in multi-AZ deployment mode, the distribution_policy parameter of warehouse[%s] can not be empty", vMap["name"].(string))
 				}
 			}
 		} else {
-			for _, v := range warehouses {
+			for i, v := range warehouses {
 				vMap := v.(map[string]interface{})
 				if len(vMap["distribution_policy"].(string)) > 0 {
-					return errors.New("in single-AZ deployment mode, the distribution_policy parameter of warehouse must be empty")
+					return warehousePath(i).GetAttr("distribution_policy").NewErrorf("in single-AZ deployment mode, the distribution_policy parameter of warehouse[%s] must be empty", vMap["name"].(string))
 				}
 			}
 		}
 	}
 
-	for _, v := range warehouses {
+	for i, v := range warehouses {
 		vMap := v.(map[string]interface{})
 		if vMap["distribution_policy"].(string) != SPECIFY_AZ && len(vMap["specify_az"].(string)) > 0 {
-			return errors.New("specify_az parameter only takes effect when the distribution_policy value is \"specify_az\"")
+			return warehousePath(i).GetAttr("specify_az").NewErrorf("specify_az parameter of warehouse[%s] only takes effect when the distribution_policy value is \"specify_az\"", vMap["name"].(string))
 		}
 	}
 
-	feArch := newCoordinatorVmInfoResp.VmInfo.Arch
+	feArch := coordinatorVmInfo.Arch
+
+	if v, ok := d.GetOk("custom_ami"); ok && len(v.([]interface{})) > 0 && (isNewResource || d.HasChange("custom_ami.0.os")) {
+		amiOs := d.Get("custom_ami.0.os").(string)
+		// The base images only narrow customAmiOsList, which the schema already checks, so the check falls back to
+		// it when they can't be listed.
+		supportedOs, err := listBaseImageOs(ctx, cluster.NewClustersAPI(c), csp, region, feArch)
+		if err != nil {
+			log.Printf("[WARN] %v, falling back to the supported os list %v", err, customAmiOsList)
+			supportedOs = customAmiOsList
+		} else if len(supportedOs) == 0 {
+			log.Printf("[WARN] no base image of %s/%s for architecture %s, falling back to the supported os list %v", csp, region, feArch, customAmiOsList)
+			supportedOs = customAmiOsList
+		}
+		if !cluster.Contains(supportedOs, amiOs) {
+			return cty.GetAttrPath("custom_ami").IndexInt(0).GetAttr("os").NewErrorf("os %s is not supported in %s/%s for architecture %s, supported: %v", amiOs, csp, region, feArch, supportedOs)
+		}
+	}
 
 	if d.HasChange("coordinator_node_size") && !isNewResource {
 		o, _ := d.GetChange("coordinator_node_size")
-		oldVmInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-			Csp:         csp,
-			Region:      region,
-			ProcessType: string(cluster.ClusterModuleTypeFE),
-			VmCate:      o.(string),
-		})
+		oldVmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeFE), o.(string), cty.GetAttrPath("coordinator_node_size"))
 		if err != nil {
-			log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, o.(string), err)
-			return fmt.Errorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, o.(string), err.Error())
-		}
-		if oldVmInfoResp.VmInfo == nil {
-			return fmt.Errorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, o.(string))
+			return err
 		}
-		if feArch != oldVmInfoResp.VmInfo.Arch {
-			return fmt.Errorf("the vm instance architecture can not be changed, csp:%s region:%s oldVmCate:%s  newVmCate:%s", csp, region, o.(string), n.(string))
+		if feArch != oldVmInfo.Arch {
+			return cty.GetAttrPath("coordinator_node_size").NewErrorf("the vm instance architecture can not be changed, csp:%s region:%s oldVmCate:%s  newVmCate:%s", csp, region, o.(string), n.(string))
 		}
 	}
 
@@ -731,23 +768,21 @@ func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}
 		oldVolumeSize, newVolumeSize := oldVolumeConfig["vol_size"].(int), newVolumeConfig["vol_size"].(int)
 
 		if newVolumeSize < oldVolumeSize {
-			return fmt.Errorf("the coordinator node `vol_size` does not support decrease")
+			return cty.GetAttrPath("coordinator_node_volume_config").IndexInt(0).GetAttr("vol_size").NewErrorf("the coordinator node `vol_size` does not support decrease")
 		}
 	}
 
-	if !newCoordinatorVmInfoResp.VmInfo.IsInstanceStore {
+	if !coordinatorVmInfo.IsInstanceStore {
 		if v, ok := d.GetOk("coordinator_node_volume_config"); ok {
-			nodeType := "Coordinator node"
-			volumeCate := newCoordinatorVmInfoResp.VmInfo.VmVolumeInfos[0].VolumeCate
-			volumeConfig := v.([]interface{})[0].(map[string]interface{})
 			err = VolumeParamVerify(ctx, &VolumeParamVerifyReq{
-				ClusterAPI:   clusterAPI,
-				VolumeCate:   volumeCate,
-				VolumeConfig: volumeConfig,
+				VmCatalog:    vmCatalog,
+				NodeType:     "Coordinator node",
+				VolumeCate:   coordinatorVmInfo.VmVolumeInfos[0].VolumeCate,
+				VolumeConfig: v.([]interface{})[0].(map[string]interface{}),
+				Path:         cty.GetAttrPath("coordinator_node_volume_config").IndexInt(0),
 			})
 			if err != nil {
-				log.Printf("[ERROR] verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-				return fmt.Errorf("verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
+				return err
 			}
 		}
 	}
@@ -756,53 +791,11 @@ func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}
 		_, n := d.GetChange("default_warehouse")
 
 		// Check vm arch
-		whVmInfoMap := make(map[string]*cluster.VMInfo)
-		for _, item := range n.([]interface{}) {
-			m := item.(map[string]interface{})
-			whName := strings.TrimSpace(m["name"].(string))
-			vmCateName := m["compute_node_size"].(string)
-			vmCateInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-				Csp:         csp,
-				Region:      region,
-				ProcessType: string(cluster.ClusterModuleTypeBE),
-				VmCate:      vmCateName,
-			})
+		for i, item := range n.([]interface{}) {
+			err := verifyWarehouseVmInfo(ctx, vmCatalog, csp, region, feArch, cty.GetAttrPath("default_warehouse").IndexInt(i), item.(map[string]interface{}))
 			if err != nil {
-				log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, vmCateName, err)
-				return fmt.Errorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, vmCateName, err.Error())
-			}
-			if vmCateInfoResp.VmInfo == nil {
-				return fmt.Errorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, vmCateName)
-			}
-			if vmCateInfoResp.VmInfo.Arch != feArch {
-				return fmt.Errorf("the vm instance`s architecture of the warehouse[%s] must be the same as the coordinator node, expect:%s but found:%s", whName, feArch, vmCateInfoResp.VmInfo.Arch)
-			}
-
-			if vmCateInfoResp.VmInfo.IsInstanceStore {
-				attrs := make([]string, 0)
-				if v, ok := m["compute_node_volume_config"]; ok && len(v.([]interface{})) > 0 {
-					attrs = append(attrs, "compute_node_volume_config")
-				}
-				if len(attrs) > 0 {
-					return fmt.Errorf("the vm instance type[%s] of the warehouse[%s] does not support specifying the volume config of disks, field: %+v is not supported", vmCateName, whName, strings.Join(attrs, ","))
-				}
-			} else {
-				if v, ok := m["compute_node_volume_config"]; ok && len(v.([]interface{})) > 0 {
-					nodeType := "Compute node"
-					volumeCate := vmCateInfoResp.VmInfo.VmVolumeInfos[0].VolumeCate
-					volumeConfig := v.([]interface{})[0].(map[string]interface{})
-					err = VolumeParamVerify(ctx, &VolumeParamVerifyReq{
-						ClusterAPI:   clusterAPI,
-						VolumeCate:   volumeCate,
-						VolumeConfig: volumeConfig,
-					})
-					if err != nil {
-						log.Printf("[ERROR] verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-						return fmt.Errorf("verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-					}
-				}
+				return err
 			}
-			whVmInfoMap[whName] = vmCateInfoResp.VmInfo
 		}
 	}
 
@@ -810,73 +803,22 @@ func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}
 
 		_, n := d.GetChange("warehouse")
 		// 1. pre check, warehosue name must be unique
-		countMap := make(map[string]int, 0)
-		for _, item := range n.([]interface{}) {
+		nameIdx := make(map[string]int, 0)
+		for i, item := range n.([]interface{}) {
 			m := item.(map[string]interface{})
 			whName := strings.TrimSpace(m["name"].(string))
-			if v, ok := countMap[whName]; ok {
-				v++
-				countMap[whName] = v
-			} else {
-				countMap[whName] = 1
-			}
-		}
-
-		for k, v := range countMap {
-			if v > 1 {
-				return fmt.Errorf("only one warehouse with name '%s' is allowed", k)
+			if _, ok := nameIdx[whName]; ok {
+				return cty.GetAttrPath("warehouse").IndexInt(i).GetAttr("name").NewErrorf("only one warehouse with name '%s' is allowed", whName)
 			}
+			nameIdx[whName] = i
 		}
 
 		// 2. check vm arch
-		whVmInfoMap := make(map[string]*cluster.VMInfo)
-		for _, item := range n.([]interface{}) {
-			m := item.(map[string]interface{})
-			whName := strings.TrimSpace(m["name"].(string))
-			vmCateName := m["compute_node_size"].(string)
-			vmCateInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-				Csp:         csp,
-				Region:      region,
-				ProcessType: string(cluster.ClusterModuleTypeBE),
-				VmCate:      vmCateName,
-			})
+		for i, item := range n.([]interface{}) {
+			err := verifyWarehouseVmInfo(ctx, vmCatalog, csp, region, feArch, cty.GetAttrPath("warehouse").IndexInt(i), item.(map[string]interface{}))
 			if err != nil {
-				log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, vmCateName, err)
-				return fmt.Errorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, vmCateName, err.Error())
-			}
-			if vmCateInfoResp.VmInfo == nil {
-				return fmt.Errorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, vmCateName)
+				return err
 			}
-			if vmCateInfoResp.VmInfo.Arch != feArch {
-				return fmt.Errorf("the vm instance`s architecture of the warehouse[%s] must be the same as the coordinator node, expect:%s but found:%s", whName, feArch, vmCateInfoResp.VmInfo.Arch)
-			}
-
-			if vmCateInfoResp.VmInfo.IsInstanceStore {
-				attrs := make([]string, 0)
-				if v, ok := m["compute_node_volume_config"]; ok && len(v.([]interface{})) > 0 {
-					attrs = append(attrs, "compute_node_volume_config")
-				}
-				if len(attrs) > 0 {
-					return fmt.Errorf("the vm instance type[%s] of the warehouse[%s] does not support specifying the volume config of disks, field: %+v is not supported", vmCateName, whName, strings.Join(attrs, ","))
-				}
-			} else {
-				if v, ok := m["compute_node_volume_config"]; ok && len(v.([]interface{})) > 0 {
-					nodeType := "Compute node"
-					volumeCate := vmCateInfoResp.VmInfo.VmVolumeInfos[0].VolumeCate
-					volumeConfig := v.([]interface{})[0].(map[string]interface{})
-					err = VolumeParamVerify(ctx, &VolumeParamVerifyReq{
-						ClusterAPI:   clusterAPI,
-						VolumeCate:   volumeCate,
-						VolumeConfig: volumeConfig,
-					})
-					if err != nil {
-						log.Printf("[ERROR] verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-						return fmt.Errorf("verify %s volume params failed, volumeCate:%s volumeConfig:%+v err:%+v", nodeType, volumeCate, volumeConfig, err)
-					}
-				}
-			}
-
-			whVmInfoMap[whName] = vmCateInfoResp.VmInfo
 		}
 	}
 
@@ -889,7 +831,7 @@ func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}
 	if v, ok := d.GetOk("resource_tags"); ok {
 		for k := range v.(map[string]interface{}) {
 			if IsInternalTagKeys(csp, k) {
-				return fmt.Errorf("cluster tag key %s is reserved for internal use and cannot be set", k)
+				return cty.GetAttrPath("resource_tags").IndexString(k).NewErrorf("cluster tag key %s is reserved for internal use and cannot be set", k)
 			}
 
 			clusterTagSet[k] = true
@@ -904,7 +846,7 @@ func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}
 			if rt, ok := defWh["resource_tags"]; ok && rt != nil {
 				for k := range rt.(map[string]interface{}) {
 					if IsInternalTagKeys(csp, k) {
-						return fmt.Errorf("default warehouse tag key %s is reserved for internal use and cannot be set", k)
+						return cty.GetAttrPath("default_warehouse").IndexInt(0).GetAttr("resource_tags").IndexString(k).NewErrorf("default warehouse tag key %s is reserved for internal use and cannot be set", k)
 					}
 					whTagSet[k] = true
 				}
@@ -913,13 +855,13 @@ func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}
 	}
 
 	if v, ok := d.GetOk("warehouse"); ok {
-		for _, item := range v.([]interface{}) {
+		for i, item := range v.([]interface{}) {
 			wh := item.(map[string]interface{})
 			whName := strings.TrimSpace(wh["name"].(string))
 			if rt, ok := wh["resource_tags"]; ok && rt != nil {
 				for k := range rt.(map[string]interface{}) {
 					if IsInternalTagKeys(csp, k) {
-						return fmt.Errorf("warehouse:%s tag key %s is reserved for internal use and cannot be set", whName, k)
+						return cty.GetAttrPath("warehouse").IndexInt(i).GetAttr("resource_tags").IndexString(k).NewErrorf("warehouse:%s tag key %s is reserved for internal use and cannot be set", whName, k)
 					}
 
 					whTagSet[k] = true
@@ -939,8 +881,159 @@ func customizeEl2Diff(ctx context.Context, d *schema.ResourceDiff, m interface{}
 		return err
 	}
 
-	err2 := SchedulingPolicyParamCheck(d)
-	return err2
+	err = SchedulingPolicyParamCheck(d)
+	if err != nil {
+		return err
+	}
+
+	if !isNewResource {
+		return setPlannedOperations(d, multiAz)
+	}
+	return nil
+}
+
+// flattenWarehouse returns the attributes of the warehouse wh in the schema of the `warehouse` block. The scheduling
+// policies are only listed when withSchedulingPolicies is set, the endpoint isn't served by every deployment.
+func flattenWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId, csp string, multiAz bool, wh *cluster.Warehouse, withSchedulingPolicies bool) (map[string]interface{}, diag.Diagnostics) {
+	warehouseId := wh.Id
+	isDefaultWarehouse := wh.IsDefaultWarehouse
+
+	whTags := make(map[string]string)
+	for k, v := range wh.Tags {
+		if !IsInternalTagKeys(csp, k) {
+			whTags[k] = v
+		}
+	}
+	whMap := make(map[string]interface{}, 0)
+	whMap["name"] = wh.Name
+	whMap["compute_node_size"] = wh.Module.InstanceType
+	whMap["compute_node_count"] = wh.Module.Num
+	whMap["resource_tags"] = whTags
+	if !multiAz {
+		whMap["distribution_policy"] = ""
+	} else {
+		whMap["distribution_policy"] = wh.DistributionPolicyStr
+	}
+	whMap["specify_az"] = wh.SpecifyAZ
+
+	whModule := wh.Module
+	if !whModule.IsInstanceStore {
+		computeNodeVolumeConfig := make(map[string]interface{}, 0)
+		computeNodeVolumeConfig["vol_number"] = whModule.VmVolNum
+		computeNodeVolumeConfig["vol_size"] = whModule.VmVolSizeGB
+		computeNodeVolumeConfig["iops"] = whModule.Iops
+		computeNodeVolumeConfig["throughput"] = whModule.Throughput
+		whMap["compute_node_volume_config"] = []interface{}{computeNodeVolumeConfig}
+	}
+
+	autoScalingConfigResp, err := clusterAPI.GetWarehouseAutoScalingConfig(ctx, &cluster.GetWarehouseAutoScalingConfigReq{
+		WarehouseId: warehouseId,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Query warehouse auto scaling config failed, warehouseId:%s", warehouseId)
+		return nil, diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to get warehouse auto scaling config, warehouseId:[%s] ", warehouseId),
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	whMap["auto_scaling_policy"] = autoScalingPolicyJson(autoScalingConfigResp.Policy)
+
+	computeNodeConfigsResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
+		ClusterID:   clusterId,
+		ConfigType:  cluster.CustomConfigTypeBE,
+		WarehouseID: warehouseId,
+	})
+	if err != nil {
+		log.Printf("[ERROR] query cluster custom config failed, err:%+v", err)
+		return nil, diag.FromErr(err)
+	}
+	if len(computeNodeConfigsResp.Configs) > 0 {
+		whMap["compute_node_configs"] = computeNodeConfigsResp.Configs
+	}
+
+	if !isDefaultWarehouse {
+		whMap["expected_state"] = wh.State
+		idleConfigResp, err := clusterAPI.GetWarehouseIdleConfig(ctx, &cluster.GetWarehouseIdleConfigReq{
+			WarehouseId: warehouseId,
+		})
+		if err != nil {
+			log.Printf("[ERROR] Query warehouse idle suspend config failed, warehouseId:%s", warehouseId)
+			return nil, diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Failed to get warehouse idle suspend config, warehouseId:[%s] ", warehouseId),
+					Detail:   err.Error(),
+				},
+			}
+		}
+		idleConfig := idleConfigResp.Config
+		if idleConfig != nil && idleConfig.State {
+			whMap["idle_suspend_interval"] = idleConfig.IntervalMs / 1000 / 60
+		} else {
+			whMap["idle_suspend_interval"] = 0
+		}
+
+		if withSchedulingPolicies {
+			policies, _, err := listWarehouseSchedulingPolicies(ctx, clusterAPI, warehouseId)
+			if errors.Is(err, errWarehouseSchedulingPolicyUnavailable) {
+				log.Printf("[WARN] %s, warehouseId:%s", err.Error(), warehouseId)
+				policies, err = []map[string]interface{}{}, nil
+			}
+			if err != nil {
+				return nil, diag.FromErr(fmt.Errorf("failed to get the scheduling policies of warehouse[%s]: %s", warehouseId, err.Error()))
+			}
+			whMap["scheduling_policy"] = policies
+		}
+	}
+
+	return whMap, nil
+}
+
+func validateWarehouseName(i interface{}, k string) (warnings []string, errors []error) {
+	whName := i.(string)
+	if len(whName) == 0 {
+		errors = append(errors, fmt.Errorf("%s`s value is invalid. Warehouse name can not be empty", k))
+	} else if whName == DEFAULT_WAREHOUSE_NAME {
+		errors = append(errors, fmt.Errorf("%s`s value is invalid. Normal warehouses can't be named: %s", k, DEFAULT_WAREHOUSE_NAME))
+	} else if strings.Contains(whName, "-") {
+		errors = append(errors, fmt.Errorf("%s`s value is invalid. Warehouse name can contain '-'", k))
+	}
+	return warnings, errors
+}
+
+// verifyWarehouseVmInfo checks the compute node size and volume config of the warehouse at path.
+func verifyWarehouseVmInfo(ctx context.Context, vmCatalog *cluster.VmCatalog, csp, region, feArch string, path cty.Path, m map[string]interface{}) error {
+	whName := strings.TrimSpace(m["name"].(string))
+	vmCateName := m["compute_node_size"].(string)
+	vmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeBE), vmCateName, path.GetAttr("compute_node_size"))
+	if err != nil {
+		return err
+	}
+	if vmInfo.Arch != feArch {
+		return path.GetAttr("compute_node_size").NewErrorf("the vm instance`s architecture of the warehouse[%s] must be the same as the coordinator node, expect:%s but found:%s", whName, feArch, vmInfo.Arch)
+	}
+
+	v, ok := m["compute_node_volume_config"]
+	if !ok || len(v.([]interface{})) == 0 {
+		return nil
+	}
+
+	volumePath := path.GetAttr("compute_node_volume_config").IndexInt(0)
+	if vmInfo.IsInstanceStore {
+		return volumePath.NewErrorf("the vm instance type[%s] of the warehouse[%s] does not support specifying the volume config of disks, field: compute_node_volume_config is not supported", vmCateName, whName)
+	}
+
+	return VolumeParamVerify(ctx, &VolumeParamVerifyReq{
+		VmCatalog:    vmCatalog,
+		NodeType:     fmt.Sprintf("Compute node of the warehouse[%s]", whName),
+		VolumeCate:   vmInfo.VmVolumeInfos[0].VolumeCate,
+		VolumeConfig: v.([]interface{})[0].(map[string]interface{}),
+		Path:         volumePath,
+	})
 }
 
 func resourceElasticClusterV2Create(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
@@ -954,7 +1047,7 @@ func resourceElasticClusterV2Create(ctx context.Context, d *schema.ResourceData,
 		Csp:                          d.Get("csp").(string),
 		Region:                       d.Get("region").(string),
 		ClusterType:                  cluster.ClusterTypeElasic,
-		Password:                     d.Get("default_admin_password").(string),
+		Password:                     getSecret(d, "default_admin_password"),
 		SslConnEnable:                true,
 		NetIfaceId:                   d.Get("network_id").(string),
 		DeployCredlId:                d.Get("deployment_credential_id").(string),
@@ -1103,7 +1196,7 @@ func resourceElasticClusterV2Create(ctx context.Context, d *schema.ResourceData,
 		clusterAPI: clusterAPI,
 		clusterID:  resp.ClusterID,
 		actionID:   resp.ActionID,
-		timeout:    common.DeployOrScaleClusterTimeout,
+		timeout:    d.Timeout(schema.TimeoutCreate),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -1222,7 +1315,7 @@ func resourceElasticClusterV2Create(ctx context.Context, d *schema.ResourceData,
 		policies := v.([]interface{})
 		for _, item := range policies {
 			m := item.(map[string]interface{})
-			err := SaveClusterSchedulingPolicy(ctx, clusterAPI, clusterId, m)
+			_, err := SaveClusterSchedulingPolicy(ctx, clusterAPI, clusterId, m)
 			if err != nil {
 				return diag.Diagnostics{
 					diag.Diagnostic{
@@ -1253,7 +1346,7 @@ func resourceElasticClusterV2Create(ctx context.Context, d *schema.ResourceData,
 
 	// create normal warehouses
 	for _, v := range normalWhMaps {
-		errDiag := createWarehouse(ctx, clusterAPI, clusterId, v)
+		_, errDiag := createWarehouse(ctx, clusterAPI, clusterId, v)
 		if errDiag != nil {
 			return diag.Diagnostics{
 				diag.Diagnostic{
@@ -1301,7 +1394,7 @@ func resourceElasticClusterV2Read(ctx context.Context, d *schema.ResourceData, m
 	stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterId,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutRead),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -1358,6 +1451,7 @@ func resourceElasticClusterV2Read(ctx context.Context, d *schema.ResourceData, m
 		log.Printf("[ERROR] query cluster custom config failed, err:%+v", err)
 		return diag.FromErr(err)
 	}
+	coordinatorNodeConfigsResp.Configs = withoutLdapConfigs(coordinatorNodeConfigsResp.Configs, d.Get("coordinator_node_configs"))
 
 	globalSessionVariables := make(map[string]string)
 	if v, ok := d.GetOk("global_session_variables"); ok && len(v.(map[string]interface{})) > 0 {
@@ -1378,6 +1472,7 @@ func resourceElasticClusterV2Read(ctx context.Context, d *schema.ResourceData, m
 		log.Printf("[ERROR] list cluster schedule policy failed,clusterId:%s err:%+v", clusterId, err)
 		return diag.FromErr(err)
 	}
+	policies, policyExtraInfo = filterSchedulingPolicies(d, policies, policyExtraInfo)
 
 	terminationProtection, err := clusterAPI.GetClusterTerminationProtection(ctx, &cluster.GetClusterTerminationProtectionReq{ClusterId: clusterId})
 	if err != nil {
@@ -1447,6 +1542,17 @@ func resourceElasticClusterV2Read(ctx context.Context, d *schema.ResourceData, m
 
 	warehouseExternalInfo := make(map[string]interface{}, 0)
 
+	ignoreUnmanagedWarehouses := d.Get("ignore_unmanaged_warehouses").(bool)
+	managedWarehouses := make(map[string]bool)
+	scheduledWarehouses := make(map[string]bool)
+	for _, v := range d.Get("warehouse").([]interface{}) {
+		wh := v.(map[string]interface{})
+		managedWarehouses[wh["name"].(string)] = true
+		if policies, ok := wh["scheduling_policy"].([]interface{}); ok && len(policies) > 0 {
+			scheduledWarehouses[wh["name"].(string)] = true
+		}
+	}
+
 	for _, v := range resp.Cluster.Warehouses {
 		if v.Deleted {
 			continue
@@ -1455,88 +1561,16 @@ func resourceElasticClusterV2Read(ctx context.Context, d *schema.ResourceData, m
 		warehouseName := v.Name
 		isDefaultWarehouse := v.IsDefaultWarehouse
 
-		whTags := make(map[string]string)
-		for k, v := range v.Tags {
-			if !IsInternalTagKeys(csp, k) {
-				whTags[k] = v
-			}
-		}
-		whMap := make(map[string]interface{}, 0)
-		whMap["name"] = warehouseName
-		whMap["compute_node_size"] = v.Module.InstanceType
-		whMap["compute_node_count"] = v.Module.Num
-		whMap["resource_tags"] = whTags
-		if !netResp.Network.MultiAz {
-			whMap["distribution_policy"] = ""
-		} else {
-			whMap["distribution_policy"] = v.DistributionPolicyStr
-		}
-		whMap["specify_az"] = v.SpecifyAZ
-
-		whModule := v.Module
-		if !whModule.IsInstanceStore {
-			computeNodeVolumeConfig := make(map[string]interface{}, 0)
-			computeNodeVolumeConfig["vol_number"] = whModule.VmVolNum
-			computeNodeVolumeConfig["vol_size"] = whModule.VmVolSizeGB
-			computeNodeVolumeConfig["iops"] = whModule.Iops
-			computeNodeVolumeConfig["throughput"] = whModule.Throughput
-			whMap["compute_node_volume_config"] = []interface{}{computeNodeVolumeConfig}
-		}
-
-		autoScalingConfigResp, err := clusterAPI.GetWarehouseAutoScalingConfig(ctx, &cluster.GetWarehouseAutoScalingConfigReq{
-			WarehouseId: warehouseId,
-		})
-		if err != nil {
-			log.Printf("[ERROR] Query warehouse auto scaling config failed, warehouseId:%s", warehouseId)
-			return diag.Diagnostics{
-				diag.Diagnostic{
-					Severity: diag.Warning,
-					Summary:  fmt.Sprintf("Failed to get warehouse auto scaling config, warehouseId:[%s] ", warehouseId),
-					Detail:   err.Error(),
-				},
-			}
+		if !isDefaultWarehouse && ignoreUnmanagedWarehouses && !managedWarehouses[warehouseName] {
+			log.Printf("[DEBUG] ignore warehouse[%s] of cluster[%s], it's not managed by the cluster resource", warehouseName, clusterId)
+			continue
 		}
 
-		policy := autoScalingConfigResp.Policy
-		if policy != nil && policy.State {
-			bytes, _ := json.Marshal(policy)
-			whMap["auto_scaling_policy"] = string(bytes)
+		whMap, whDiags := flattenWarehouse(ctx, clusterAPI, clusterId, csp, netResp.Network.MultiAz, v, scheduledWarehouses[warehouseName])
+		if whDiags != nil {
+			return whDiags
 		}
-
-		computeNodeConfigsResp, err := clusterAPI.GetCustomConfig(ctx, &cluster.ListCustomConfigReq{
-			ClusterID:   clusterId,
-			ConfigType:  cluster.CustomConfigTypeBE,
-			WarehouseID: warehouseId,
-		})
-		if err != nil {
-			log.Printf("[ERROR] query cluster custom config failed, err:%+v", err)
-			return diag.FromErr(err)
-		}
-		if len(computeNodeConfigsResp.Configs) > 0 {
-			whMap["compute_node_configs"] = computeNodeConfigsResp.Configs
-		}
-
 		if !isDefaultWarehouse {
-			whMap["expected_state"] = v.State
-			idleConfigResp, err := clusterAPI.GetWarehouseIdleConfig(ctx, &cluster.GetWarehouseIdleConfigReq{
-				WarehouseId: warehouseId,
-			})
-			if err != nil {
-				log.Printf("[ERROR] Query warehouse idle suspend config failed, warehouseId:%s", warehouseId)
-				return diag.Diagnostics{
-					diag.Diagnostic{
-						Severity: diag.Warning,
-						Summary:  fmt.Sprintf("Failed to get warehouse idle suspend config, warehouseId:[%s] ", warehouseId),
-						Detail:   err.Error(),
-					},
-				}
-			}
-			idleConfig := idleConfigResp.Config
-			if idleConfig != nil && idleConfig.State {
-				whMap["idle_suspend_interval"] = idleConfig.IntervalMs / 1000 / 60
-			} else {
-				whMap["idle_suspend_interval"] = 0
-			}
 			normal_warehouses = append(normal_warehouses, whMap)
 		} else {
 			default_warehouses = append(default_warehouses, whMap)
@@ -1580,7 +1614,8 @@ func resourceElasticClusterV2Read(ctx context.Context, d *schema.ResourceData, m
 	d.Set("warehouse", normal_warehouses)
 	d.Set("warehouse_external_info", warehouseExternalInfo)
 
-	if len(coordinatorNodeConfigsResp.Configs) > 0 {
+	// The LDAP configs may have been dropped, so the configs are also set when the state has some.
+	if len(coordinatorNodeConfigsResp.Configs) > 0 || len(d.Get("coordinator_node_configs").(map[string]interface{})) > 0 {
 		d.Set("coordinator_node_configs", coordinatorNodeConfigsResp.Configs)
 	}
 
@@ -1595,10 +1630,6 @@ func resourceElasticClusterV2Read(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	if len(coordinatorNodeConfigsResp.Configs) > 0 {
-		d.Set("coordinator_node_configs", coordinatorNodeConfigsResp.Configs)
-	}
-
 	if len(globalSessionVariables) > 0 {
 		d.Set("global_session_variables", globalSessionVariables)
 	}
@@ -1627,10 +1658,14 @@ func resourceElasticClusterV2Delete(ctx context.Context, d *schema.ResourceData,
 	log.Printf("[DEBUG] resourceElasticClusterV2Delete cluster id:%s", clusterId)
 	var diags diag.Diagnostics
 
+	if diags := checkTerminationProtection(ctx, clusterAPI, clusterId); diags.HasError() {
+		return diags
+	}
+
 	_, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterId,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutDelete),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -1661,7 +1696,7 @@ func resourceElasticClusterV2Delete(ctx context.Context, d *schema.ResourceData,
 		clusterAPI: clusterAPI,
 		actionID:   resp.ActionID,
 		clusterID:  clusterId,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutDelete),
 		pendingStates: []string{
 			string(cluster.ClusterStateReleasing),
 			string(cluster.ClusterStateRunning),
@@ -1686,6 +1721,29 @@ func resourceElasticClusterV2Delete(ctx context.Context, d *schema.ResourceData,
 	return diags
 }
 
+// elasticClusterV2UpdateKeys are the attributes resourceElasticClusterV2Update changes, in the order they're changed.
+var elasticClusterV2UpdateKeys = []string{
+	"idle_suspend_interval",
+	"scheduling_policy",
+	"expected_cluster_state",
+	"global_session_variables",
+	"ldap_ssl_certs",
+	"resource_tags",
+	"init_scripts",
+	"ranger_certs_dir",
+	"enabled_termination_protection",
+	"enabled_arrow_flight",
+	"table_name_case_insensitive",
+	"coordinator_node_size",
+	"coordinator_node_count",
+	"coordinator_node_volume_config",
+	"coordinator_node_configs",
+	"default_warehouse",
+	"warehouse",
+	"ranger_config_id",
+	"custom_ami",
+}
+
 func elasticClusterV2NeedUnlock(d *schema.ResourceData) bool {
 	result := !d.IsNewResource() && d.Get("free_tier").(bool) &&
 		(d.HasChange("coordinator_node_size") || d.HasChange("coordinator_node_count"))
@@ -1697,10 +1755,13 @@ func elasticClusterV2NeedUnlock(d *schema.ResourceData) bool {
 	return result
 }
 
-func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	checkpoint := newUpdateCheckpoint(d, elasticClusterV2UpdateKeys)
+	defer checkpoint.rollback(&diags)
+
 	var immutableFields = []string{"csp", "region", "cluster_name", "default_admin_password", "data_credential_id", "deployment_credential_id", "network_id", "query_port"}
 	for _, f := range immutableFields {
-		if d.HasChange(f) && !d.IsNewResource() {
+		if d.HasChange(f) && !d.IsNewResource() && !movedToWriteOnly(d, f) {
 			return diag.FromErr(fmt.Errorf("the `%s` field is not allowed to be modified", f))
 		}
 	}
@@ -1715,7 +1776,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 	stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
 		clusterAPI: clusterAPI,
 		clusterID:  clusterId,
-		timeout:    30 * time.Minute,
+		timeout:    d.Timeout(schema.TimeoutUpdate),
 		pendingStates: []string{
 			string(cluster.ClusterStateDeploying),
 			string(cluster.ClusterStateScaling),
@@ -1758,6 +1819,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if errDiag != nil {
 			return errDiag
 		}
+		checkpoint.complete("idle_suspend_interval")
 	}
 
 	if d.HasChange("scheduling_policy") && !d.IsNewResource() {
@@ -1765,16 +1827,16 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if diagError != nil {
 			return diagError
 		}
+		checkpoint.complete("scheduling_policy")
 	}
 
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
 	if needResume(d) {
 		o, n := d.GetChange("expected_cluster_state")
 		errDiag := UpdateClusterState(ctx, clusterAPI, d.Get("id").(string), o.(string), n.(string))
 		if errDiag != nil {
 			return errDiag
 		}
+		checkpoint.complete("expected_cluster_state")
 	}
 
 	if d.HasChange("global_session_variables") && !d.IsNewResource() {
@@ -1782,6 +1844,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if errDiag != nil {
 			return errDiag
 		}
+		checkpoint.complete("global_session_variables")
 	}
 
 	if d.HasChange("ldap_ssl_certs") && !d.IsNewResource() {
@@ -1797,6 +1860,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if warningDiag != nil {
 			return warningDiag
 		}
+		checkpoint.complete("ldap_ssl_certs")
 	}
 
 	if d.HasChange("resource_tags") && !d.IsNewResource() {
@@ -1814,6 +1878,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("cluster (%s) failed to update resource tags: %s", d.Id(), err.Error()))
 		}
+		checkpoint.complete("resource_tags")
 	}
 
 	if d.HasChange("init_scripts") && !d.IsNewResource() {
@@ -1836,6 +1901,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("failed to update cluster(%s) init-scripts: %s", d.Id(), err.Error()))
 		}
+		checkpoint.complete("init_scripts")
 	}
 
 	if d.HasChange("ranger_certs_dir") && !d.IsNewResource() {
@@ -1844,6 +1910,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if warningDiag != nil {
 			return warningDiag
 		}
+		checkpoint.complete("ranger_certs_dir")
 	}
 
 	if d.HasChange("enabled_termination_protection") && !d.IsNewResource() {
@@ -1854,6 +1921,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("cluster (%s) failed to set termination protection: %s", d.Id(), err.Error()))
 		}
+		checkpoint.complete("enabled_termination_protection")
 	}
 
 	if d.HasChange("enabled_arrow_flight") && !d.IsNewResource() {
@@ -1865,6 +1933,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if diagnostics != nil {
 			return diagnostics
 		}
+		checkpoint.complete("enabled_arrow_flight")
 	}
 
 	if d.HasChange("table_name_case_insensitive") && !d.IsNewResource() {
@@ -1880,50 +1949,62 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 
 	if d.HasChange("coordinator_node_size") && !d.IsNewResource() {
 		_, n := d.GetChange("coordinator_node_size")
-		resp, err := clusterAPI.ScaleUp(ctx, &cluster.ScaleUpReq{
-			RequestId:  uuid.NewString(),
-			ClusterId:  clusterId,
-			ModuleType: cluster.ClusterModuleTypeFE,
-			VmCategory: n.(string),
-		})
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("cluster (%s) failed to scale up fe nodes: %s", d.Id(), err))
-		}
+		err := checkpoint.runAction("coordinator_node_size", moduleReached(ctx, clusterAPI, clusterId, "", func(m *cluster.Module, _ *cluster.Warehouse) bool {
+			return m.InstanceType == n.(string)
+		}), func() (string, error) {
+			resp, err := clusterAPI.ScaleUp(ctx, &cluster.ScaleUpReq{
+				RequestId:  uuid.NewString(),
+				ClusterId:  clusterId,
+				ModuleType: cluster.ClusterModuleTypeFE,
+				VmCategory: n.(string),
+			})
+			if err != nil {
+				return "", fmt.Errorf("cluster (%s) failed to scale up fe nodes: %s", d.Id(), err)
+			}
+			return resp.ActionId, nil
+		}, func(actionId string) error {
+			stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
+				clusterAPI:    clusterAPI,
+				actionID:      actionId,
+				clusterID:     clusterId,
+				timeout:       d.Timeout(schema.TimeoutUpdate),
+				pendingStates: []string{string(cluster.ClusterStateScaling)},
+				targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
+			})
+			if err != nil {
+				return fmt.Errorf("waiting for cluster (%s) running %s", d.Id(), err)
+			}
 
-		stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
-			clusterAPI:    clusterAPI,
-			actionID:      resp.ActionId,
-			clusterID:     clusterId,
-			timeout:       common.DeployOrScaleClusterTimeout,
-			pendingStates: []string{string(cluster.ClusterStateScaling)},
-			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
+			if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
+				return errors.New(stateResp.AbnormalReason)
+			}
+			return nil
 		})
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("waiting for cluster (%s) running %s", d.Id(), err))
-		}
-
-		if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
-			return diag.FromErr(errors.New(stateResp.AbnormalReason))
+			return diag.FromErr(err)
 		}
+		checkpoint.complete("coordinator_node_size")
 	}
 
 	if d.HasChange("coordinator_node_count") && !d.IsNewResource() {
 		o, n := d.GetChange("coordinator_node_count")
 
-		var actionID string
-		if n.(int) > o.(int) {
-			resp, err := clusterAPI.ScaleOut(ctx, &cluster.ScaleOutReq{
-				RequestId:  uuid.NewString(),
-				ClusterId:  clusterId,
-				ModuleType: cluster.ClusterModuleTypeFE,
-				ExpectNum:  int32(n.(int)),
-			})
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("cluster (%s) failed to scale out fe nodes: %s", d.Id(), err))
+		err := checkpoint.runAction("coordinator_node_count", moduleReached(ctx, clusterAPI, clusterId, "", func(m *cluster.Module, _ *cluster.Warehouse) bool {
+			return int(m.Num) == n.(int)
+		}), func() (string, error) {
+			if n.(int) > o.(int) {
+				resp, err := clusterAPI.ScaleOut(ctx, &cluster.ScaleOutReq{
+					RequestId:  uuid.NewString(),
+					ClusterId:  clusterId,
+					ModuleType: cluster.ClusterModuleTypeFE,
+					ExpectNum:  int32(n.(int)),
+				})
+				if err != nil {
+					return "", fmt.Errorf("cluster (%s) failed to scale out fe nodes: %s", d.Id(), err)
+				}
+				return resp.ActionId, nil
 			}
 
-			actionID = resp.ActionId
-		} else if n.(int) < o.(int) {
 			resp, err := clusterAPI.ScaleIn(ctx, &cluster.ScaleInReq{
 				RequestId:  uuid.NewString(),
 				ClusterId:  clusterId,
@@ -1931,27 +2012,31 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 				ExpectNum:  int32(n.(int)),
 			})
 			if err != nil {
-				return diag.FromErr(fmt.Errorf("cluster (%s) failed to scale in fe nodes: %s", d.Id(), err))
+				return "", fmt.Errorf("cluster (%s) failed to scale in fe nodes: %s", d.Id(), err)
+			}
+			return resp.ActionId, nil
+		}, func(actionId string) error {
+			stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
+				clusterAPI:    clusterAPI,
+				actionID:      actionId,
+				clusterID:     clusterId,
+				timeout:       d.Timeout(schema.TimeoutUpdate),
+				pendingStates: []string{string(cluster.ClusterStateScaling)},
+				targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
+			})
+			if err != nil {
+				return fmt.Errorf("waiting for cluster (%s) running: %s", d.Id(), err)
 			}
 
-			actionID = resp.ActionId
-		}
-
-		stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
-			clusterAPI:    clusterAPI,
-			actionID:      actionID,
-			clusterID:     clusterId,
-			timeout:       common.DeployOrScaleClusterTimeout,
-			pendingStates: []string{string(cluster.ClusterStateScaling)},
-			targetStates:  []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
+			if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
+				return errors.New(stateResp.AbnormalReason)
+			}
+			return nil
 		})
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("waiting for cluster (%s) running: %s", d.Id(), err))
-		}
-
-		if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
-			return diag.FromErr(errors.New(stateResp.AbnormalReason))
+			return diag.FromErr(err)
 		}
+		checkpoint.complete("coordinator_node_count")
 	}
 
 	if d.HasChange("coordinator_node_volume_config") {
@@ -1982,20 +2067,26 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 			req.Throughput = int64(v.(int))
 		}
 
-		log.Printf("[DEBUG] modify cluster volume detail, req:%+v", req)
-		resp, err := clusterAPI.ModifyClusterVolume(ctx, req)
-		if err != nil {
-			log.Printf("[ERROR] modify cluster volume detail failed, err:%+v", err)
-			return diag.FromErr(err)
-		}
+		err := checkpoint.runAction("coordinator_node_volume_config", moduleReached(ctx, clusterAPI, clusterId, "", func(m *cluster.Module, _ *cluster.Warehouse) bool {
+			return volumeReached(m, req)
+		}), func() (string, error) {
+			log.Printf("[DEBUG] modify cluster volume detail, req:%+v", req)
+			resp, err := clusterAPI.ModifyClusterVolume(ctx, req)
+			if err != nil {
+				log.Printf("[ERROR] modify cluster volume detail failed, err:%+v", err)
+				return "", err
+			}
+			return resp.ActionID, nil
+		}, func(infraActionId string) error {
+			if len(infraActionId) == 0 {
+				return nil
+			}
 
-		infraActionId := resp.ActionID
-		if len(infraActionId) > 0 {
 			infraActionResp, err := WaitClusterInfraActionStateChangeComplete(ctx, &waitStateReq{
 				clusterAPI: clusterAPI,
 				clusterID:  clusterId,
 				actionID:   infraActionId,
-				timeout:    30 * time.Minute,
+				timeout:    d.Timeout(schema.TimeoutUpdate),
 				pendingStates: []string{
 					string(cluster.ClusterInfraActionStatePending),
 					string(cluster.ClusterInfraActionStateOngoing),
@@ -2006,36 +2097,38 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 					string(cluster.ClusterInfraActionStateFailed),
 				},
 			})
-
-			summary := fmt.Sprintf("Modify %s node volume detail of the cluster[%s] failed", nodeType, clusterId)
 			if err != nil {
-				return diag.Diagnostics{
-					diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  summary,
-						Detail:   err.Error(),
-					},
-				}
+				return err
 			}
 
 			if infraActionResp.InfraActionState == string(cluster.ClusterInfraActionStateFailed) {
-				return diag.Diagnostics{
-					diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  summary,
-						Detail:   infraActionResp.ErrMsg,
-					},
-				}
+				return errors.New(infraActionResp.ErrMsg)
+			}
+			return nil
+		})
+		if err != nil {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Modify %s node volume detail of the cluster[%s] failed", nodeType, clusterId),
+					Detail:   err.Error(),
+				},
 			}
 		}
+		checkpoint.complete("coordinator_node_volume_config")
 	}
 
 	if d.HasChange("coordinator_node_configs") {
-		configMap := d.Get("coordinator_node_configs").(map[string]interface{})
+		o, n := d.GetChange("coordinator_node_configs")
 		configs := make(map[string]string, 0)
-		for k, v := range configMap {
+		for k, v := range n.(map[string]interface{}) {
 			configs[k] = v.(string)
 		}
+		// The coordinator node configs are replaced as a whole, keep the ones of celerdatabyoc_cluster_ldap.
+		configs, err := mergeClusterLdapConfigs(ctx, clusterAPI, clusterId, configs, o.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 		warnDiag := UpsertClusterConfig(ctx, clusterAPI, &cluster.UpsertClusterConfigReq{
 			ClusterID:  clusterId,
 			ConfigType: cluster.CustomConfigTypeFE,
@@ -2044,6 +2137,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if warnDiag != nil {
 			return warnDiag
 		}
+		checkpoint.complete("coordinator_node_configs")
 	}
 
 	netResp, err := networkAPI.GetNetwork(ctx, d.Get("network_id").(string))
@@ -2062,16 +2156,20 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		whExternalInfo := &cluster.WarehouseExternalInfo{}
 		json.Unmarshal([]byte(whExternalInfoStr), whExternalInfo)
 		diags := updateWarehouse(ctx, &UpdateWarehouseReq{
-			d:              d,
+			csp:            d.Get("csp").(string),
+			region:         d.Get("region").(string),
 			clusterAPI:     clusterAPI,
+			vmCatalog:      cluster.SharedVmCatalog(c),
 			clusterId:      clusterId,
 			oldParamMap:    oldWh,
 			newParamMap:    newWh,
 			whExternalInfo: whExternalInfo,
+			checkpoint:     checkpoint,
 		}, netResp.Network.MultiAz)
 		if diags != nil {
 			return diags
 		}
+		checkpoint.complete("default_warehouse")
 	}
 
 	if d.HasChange("warehouse") {
@@ -2100,23 +2198,27 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 				whExternalInfo := &cluster.WarehouseExternalInfo{}
 				json.Unmarshal([]byte(whExternalInfoStr), whExternalInfo)
 				diags := updateWarehouse(ctx, &UpdateWarehouseReq{
-					d:              d,
+					csp:            d.Get("csp").(string),
+					region:         d.Get("region").(string),
 					clusterAPI:     clusterAPI,
+					vmCatalog:      cluster.SharedVmCatalog(c),
 					clusterId:      clusterId,
 					oldParamMap:    oldWh,
 					newParamMap:    newWh,
 					whExternalInfo: whExternalInfo,
+					checkpoint:     checkpoint,
 				}, netResp.Network.MultiAz)
 				if diags != nil {
 					return diags
 				}
 			} else {
 				// added
-				diags := createWarehouse(ctx, clusterAPI, clusterId, newWh)
+				_, diags := createWarehouse(ctx, clusterAPI, clusterId, newWh)
 				if diags != nil {
 					return diags
 				}
 			}
+			checkpoint.completeWarehouse(whName)
 		}
 
 		for _, v := range old {
@@ -2132,8 +2234,10 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 				if diags != nil {
 					return diags
 				}
+				checkpoint.completeWarehouse(whName)
 			}
 		}
+		checkpoint.complete("warehouse")
 	}
 
 	RunScripts(ctx, RunScriptsReq{
@@ -2154,6 +2258,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		if warningDiag != nil {
 			return warningDiag
 		}
+		checkpoint.complete("ranger_config_id")
 	}
 
 	if d.HasChange("custom_ami") && !d.IsNewResource() {
@@ -2183,7 +2288,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 			}
 
 			for _, wh := range clusterResp.Cluster.Warehouses {
-				err := upgradeAMI(ctx, clusterAPI, &cluster.UpgradeAMIReq{
+				err := upgradeAMI(ctx, clusterAPI, checkpoint, &cluster.UpgradeAMIReq{
 					ClusterId:   clusterId,
 					Os:          nOs.(string),
 					Ami:         nAmi.(string),
@@ -2195,7 +2300,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 				}
 			}
 
-			err = upgradeAMI(ctx, clusterAPI, &cluster.UpgradeAMIReq{
+			err = upgradeAMI(ctx, clusterAPI, checkpoint, &cluster.UpgradeAMIReq{
 				ClusterId:  clusterId,
 				Os:         nOs.(string),
 				Ami:        nAmi.(string),
@@ -2205,6 +2310,7 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 				return diag.FromErr(err)
 			}
 		}
+		checkpoint.complete("custom_ami")
 	}
 
 	if needSuspend(d) {
@@ -2215,39 +2321,49 @@ func resourceElasticClusterV2Update(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	return diags
+	checkpoint.finish()
+	return append(diags, performedOperationsWarning(d, netResp.Network.MultiAz)...)
 }
 
-func upgradeAMI(ctx context.Context, clusterAPI cluster.IClusterAPI, req *cluster.UpgradeAMIReq) error {
-	resp, err := clusterAPI.UpgradeAMI(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to update custom ami, %s. %s", err.Error(), req)
+func upgradeAMI(ctx context.Context, clusterAPI cluster.IClusterAPI, checkpoint *updateCheckpoint, req *cluster.UpgradeAMIReq) error {
+	step := fmt.Sprintf("custom_ami[%s]", req.ModuleType)
+	if len(req.WarehouseId) > 0 {
+		step = fmt.Sprintf("custom_ami[%s]", req.WarehouseId)
 	}
 
-	infraActionResp, err := WaitClusterInfraActionStateChangeComplete(ctx, &waitStateReq{
-		clusterAPI: clusterAPI,
-		clusterID:  req.ClusterId,
-		actionID:   resp.InfraActionId,
-		timeout:    common.DeployOrScaleClusterTimeout,
-		pendingStates: []string{
-			string(cluster.ClusterInfraActionStatePending),
-			string(cluster.ClusterInfraActionStateOngoing),
-		},
-		targetStates: []string{
-			string(cluster.ClusterInfraActionStateSucceeded),
-			string(cluster.ClusterInfraActionStateCompleted),
-			string(cluster.ClusterInfraActionStateFailed),
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("failed to wait upgrade ami, %s. action:%s,%s", err.Error(), resp.InfraActionId, req)
-	}
-
-	if infraActionResp.InfraActionState == string(cluster.ClusterInfraActionStateFailed) {
-		return fmt.Errorf("failed to wait upgrade ami, %s. action:%s,%s", infraActionResp.ErrMsg, resp.InfraActionId, req)
-	}
+	return checkpoint.runAction(step, moduleReached(ctx, clusterAPI, req.ClusterId, req.WarehouseId, func(m *cluster.Module, _ *cluster.Warehouse) bool {
+		return m.AmiId == req.Ami
+	}), func() (string, error) {
+		resp, err := clusterAPI.UpgradeAMI(ctx, req)
+		if err != nil {
+			return "", fmt.Errorf("failed to update custom ami, %s. %s", err.Error(), req)
+		}
+		return resp.InfraActionId, nil
+	}, func(infraActionId string) error {
+		infraActionResp, err := WaitClusterInfraActionStateChangeComplete(ctx, &waitStateReq{
+			clusterAPI: clusterAPI,
+			clusterID:  req.ClusterId,
+			actionID:   infraActionId,
+			timeout:    timeoutFromContext(ctx),
+			pendingStates: []string{
+				string(cluster.ClusterInfraActionStatePending),
+				string(cluster.ClusterInfraActionStateOngoing),
+			},
+			targetStates: []string{
+				string(cluster.ClusterInfraActionStateSucceeded),
+				string(cluster.ClusterInfraActionStateCompleted),
+				string(cluster.ClusterInfraActionStateFailed),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to wait upgrade ami, %s. action:%s,%s", err.Error(), infraActionId, req)
+		}
 
-	return nil
+		if infraActionResp.InfraActionState == string(cluster.ClusterInfraActionStateFailed) {
+			return fmt.Errorf("failed to wait upgrade ami, %s. action:%s,%s", infraActionResp.ErrMsg, infraActionId, req)
+		}
+		return nil
+	})
 }
 
 func setWarehouseAutoScalingPolicy(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId, warehouseId, policyJson string) error {
@@ -2267,7 +2383,7 @@ func setWarehouseAutoScalingPolicy(ctx context.Context, clusterAPI cluster.IClus
 	return nil
 }
 
-func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, whParamMap map[string]interface{}) diag.Diagnostics {
+func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, whParamMap map[string]interface{}) (string, diag.Diagnostics) {
 
 	warehouseName := whParamMap["name"].(string)
 
@@ -2319,7 +2435,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 	resp, err := clusterAPI.CreateWarehouse(ctx, req)
 	if err != nil {
 		log.Printf("[ERROR] Create warehouse failed, err:%+v", err)
-		return diag.FromErr(err)
+		return "", diag.FromErr(err)
 	}
 	log.Printf("[DEBUG] Create warehouse, resp:%+v", resp)
 
@@ -2330,7 +2446,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 			clusterAPI: clusterAPI,
 			clusterID:  clusterId,
 			actionID:   resp.ActionID,
-			timeout:    common.DeployOrScaleClusterTimeout,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterStateDeploying),
 				string(cluster.ClusterStateScaling),
@@ -2347,11 +2463,11 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 
 		if err != nil {
 			summary := fmt.Sprintf("create warehouse[%s] of the cluster[%s] failed, errMsg:%s", warehouseName, clusterId, err.Error())
-			return diag.FromErr(fmt.Errorf("%s", summary))
+			return warehouseId, diag.FromErr(fmt.Errorf("%s", summary))
 		}
 
 		if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
-			return diag.FromErr(errors.New(stateResp.AbnormalReason))
+			return warehouseId, diag.FromErr(errors.New(stateResp.AbnormalReason))
 		}
 	}
 
@@ -2361,7 +2477,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 		if err != nil {
 			msg := fmt.Sprintf("Add warehouse auto-scaling configuration failed, errMsg:%s", err.Error())
 			log.Printf("[ERROR] %s", msg)
-			return diag.Diagnostics{
+			return warehouseId, diag.Diagnostics{
 				diag.Diagnostic{
 					Severity: diag.Warning,
 					Summary:  fmt.Sprintf("Config warehouse[%s] auto-scaling configuration failed", warehouseName),
@@ -2384,7 +2500,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 			Configs:     configs,
 		})
 		if warnDiag != nil {
-			return warnDiag
+			return warehouseId, warnDiag
 		}
 	}
 
@@ -2395,7 +2511,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 			WarehouseId: warehouseId,
 		})
 		if err != nil {
-			return diag.Diagnostics{
+			return warehouseId, diag.Diagnostics{
 				diag.Diagnostic{
 					Severity: diag.Warning,
 					Summary:  summary,
@@ -2409,7 +2525,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 				clusterAPI: clusterAPI,
 				clusterID:  clusterId,
 				actionID:   infraActionId,
-				timeout:    common.DeployOrScaleClusterTimeout,
+				timeout:    timeoutFromContext(ctx),
 				pendingStates: []string{
 					string(cluster.ClusterStateDeploying),
 					string(cluster.ClusterStateScaling),
@@ -2426,7 +2542,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 
 			summary := fmt.Sprintf("suspend warehouse[%s] failed", warehouseName)
 			if err != nil {
-				return diag.Diagnostics{
+				return warehouseId, diag.Diagnostics{
 					diag.Diagnostic{
 						Severity: diag.Warning,
 						Summary:  summary,
@@ -2436,7 +2552,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 			}
 
 			if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
-				return diag.Diagnostics{
+				return warehouseId, diag.Diagnostics{
 					diag.Diagnostic{
 						Severity: diag.Warning,
 						Summary:  summary,
@@ -2455,7 +2571,7 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 			State:       true,
 		})
 		if err != nil {
-			return diag.Diagnostics{
+			return warehouseId, diag.Diagnostics{
 				diag.Diagnostic{
 					Severity: diag.Warning,
 					Summary:  fmt.Sprintf("Config warehouse[%s] idle config failed", warehouseName),
@@ -2464,16 +2580,30 @@ func createWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 			}
 		}
 	}
-	return nil
+
+	if v, ok := whParamMap["scheduling_policy"]; ok && len(v.([]interface{})) > 0 {
+		err = updateWarehouseSchedulingPolicies(ctx, clusterAPI, warehouseId, v.([]interface{}))
+		if err != nil {
+			return warehouseId, diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Config warehouse[%s] scheduling policies failed", warehouseName),
+					Detail:   err.Error(),
+				},
+			}
+		}
+	}
+	return warehouseId, nil
 }
 
 func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool) diag.Diagnostics {
-	csp := req.d.Get("csp").(string)
-	region := req.d.Get("region").(string)
+	csp := req.csp
+	region := req.region
 	clusterAPI := req.clusterAPI
 	clusterId := req.clusterId
 	oldParamMap, newParamMap := req.oldParamMap, req.newParamMap
 	whExternalInfo := req.whExternalInfo
+	checkpoint := req.checkpoint
 
 	warehouseId := whExternalInfo.Id
 	isDefaultWarehouse := whExternalInfo.IsDefaultWarehouse
@@ -2499,37 +2629,47 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 	if computeNodeDistributionChanged && multiAz {
 		distributionPolicy := newParamMap["distribution_policy"].(string)
 		specifyAz := newParamMap["specify_az"].(string)
-		resp, err := clusterAPI.ChangeWarehouseDistribution(ctx, &cluster.ChangeWarehouseDistributionReq{
-			WarehouseID:        warehouseId,
-			DistributionPolicy: distributionPolicy,
-			SpecifyAz:          specifyAz,
-		})
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to change warehouse distribution, clusterId:%s warehouseId:%s, errMsg:%s", clusterId, warehouseId, err.Error()))
-		}
+		step := fmt.Sprintf("warehouse[%s].distribution_policy", warehouseName)
+		err := checkpoint.runAction(step, moduleReached(ctx, clusterAPI, clusterId, warehouseId, func(_ *cluster.Module, wh *cluster.Warehouse) bool {
+			return wh.DistributionPolicyStr == distributionPolicy && (distributionPolicy != string(cluster.DistributionPolicySpecifyAZ) || wh.SpecifyAZ == specifyAz)
+		}), func() (string, error) {
+			resp, err := clusterAPI.ChangeWarehouseDistribution(ctx, &cluster.ChangeWarehouseDistributionReq{
+				WarehouseID:        warehouseId,
+				DistributionPolicy: distributionPolicy,
+				SpecifyAz:          specifyAz,
+			})
+			if err != nil {
+				return "", fmt.Errorf("failed to change warehouse distribution, clusterId:%s warehouseId:%s, errMsg:%s", clusterId, warehouseId, err.Error())
+			}
+			return resp.InfraActionId, nil
+		}, func(infraActionId string) error {
+			infraActionResp, err := WaitClusterInfraActionStateChangeComplete(ctx, &waitStateReq{
+				clusterAPI: clusterAPI,
+				clusterID:  clusterId,
+				actionID:   infraActionId,
+				timeout:    timeoutFromContext(ctx),
+				pendingStates: []string{
+					string(cluster.ClusterInfraActionStatePending),
+					string(cluster.ClusterInfraActionStateOngoing),
+				},
+				targetStates: []string{
+					string(cluster.ClusterInfraActionStateSucceeded),
+					string(cluster.ClusterInfraActionStateCompleted),
+					string(cluster.ClusterInfraActionStateFailed),
+				},
+			})
 
-		infraActionResp, err := WaitClusterInfraActionStateChangeComplete(ctx, &waitStateReq{
-			clusterAPI: clusterAPI,
-			clusterID:  clusterId,
-			actionID:   resp.InfraActionId,
-			timeout:    common.DeployOrScaleClusterTimeout,
-			pendingStates: []string{
-				string(cluster.ClusterInfraActionStatePending),
-				string(cluster.ClusterInfraActionStateOngoing),
-			},
-			targetStates: []string{
-				string(cluster.ClusterInfraActionStateSucceeded),
-				string(cluster.ClusterInfraActionStateCompleted),
-				string(cluster.ClusterInfraActionStateFailed),
-			},
-		})
+			if err != nil {
+				return fmt.Errorf("failed to wait change warehouse distribution[%s], clusterId:%s warehouseId:%s, errMsg:%s", infraActionId, clusterId, warehouseId, err.Error())
+			}
 
+			if infraActionResp.InfraActionState == string(cluster.ClusterInfraActionStateFailed) {
+				return fmt.Errorf("failed to wait change warehouse distribution[%s], clusterId:%s warehouseId:%s, errMsg:%s", infraActionId, clusterId, warehouseId, infraActionResp.ErrMsg)
+			}
+			return nil
+		})
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to wait change warehouse distribution[%s], clusterId:%s warehouseId:%s, errMsg:%s", resp.InfraActionId, clusterId, warehouseId, err.Error()))
-		}
-
-		if infraActionResp.InfraActionState == string(cluster.ClusterInfraActionStateFailed) {
-			return diag.FromErr(fmt.Errorf("failed to wait change warehouse distribution[%s], clusterId:%s warehouseId:%s, errMsg:%s", resp.InfraActionId, clusterId, warehouseId, infraActionResp.ErrMsg))
+			return diag.FromErr(err)
 		}
 	}
 
@@ -2537,18 +2677,13 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 	computeNodeSizeChanged := oldParamMap["compute_node_size"].(string) != newParamMap["compute_node_size"].(string)
 	if computeNodeSizeChanged {
 		vmCate := newParamMap["compute_node_size"].(string)
-		vmCateInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-			Csp:         csp,
-			Region:      region,
-			ProcessType: string(cluster.ClusterModuleTypeBE),
-			VmCate:      vmCate,
-		})
+		vmInfo, err := req.vmCatalog.Get(ctx, csp, region, string(cluster.ClusterModuleTypeBE), vmCate)
 		if err != nil {
 			log.Printf("[ERROR] query vm info failed, csp:%s region:%s vmCate:%s err:%+v", csp, region, vmCate, err)
 			return diag.FromErr(fmt.Errorf("query vm info failed, csp:%s region:%s vmCate:%s errMsg:%s", csp, region, vmCate, err.Error()))
 		}
 
-		if vmCateInfoResp.VmInfo == nil {
+		if vmInfo == nil {
 			return diag.FromErr(fmt.Errorf("vm info not exists, csp:%s region:%s vmCate:%s", csp, region, vmCate))
 		}
 
@@ -2557,14 +2692,14 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 			VmCate:      vmCate,
 		}
 
-		if computeNodeIsInstanceStore && !vmCateInfoResp.VmInfo.IsInstanceStore {
+		if computeNodeIsInstanceStore && !vmInfo.IsInstanceStore {
 			newVolumeConfig := cluster.DefaultBeVolumeMap()
 			if len(newParamMap["compute_node_volume_config"].([]interface{})) > 0 {
 				newVolumeConfig = newParamMap["compute_node_volume_config"].([]interface{})[0].(map[string]interface{})
 			}
 
-			if newVolumeConfig["vol_number"].(int) > int(vmCateInfoResp.VmInfo.MaxDataDiskCount) {
-				return diag.FromErr(fmt.Errorf("the maximum allowed `vol_number` for this VM type is: %d", vmCateInfoResp.VmInfo.MaxDataDiskCount))
+			if newVolumeConfig["vol_number"].(int) > int(vmInfo.MaxDataDiskCount) {
+				return diag.FromErr(fmt.Errorf("the maximum allowed `vol_number` for this VM type is: %d", vmInfo.MaxDataDiskCount))
 			}
 
 			if newVolumeConfig["vol_number"].(int) < 1 {
@@ -2585,28 +2720,18 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 			}
 		}
 
-		resp, err := clusterAPI.ScaleWarehouseSize(ctx, scaleReq)
-
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to scale warehouse size, clusterId:%s warehouseId:%s, errMsg:%s", clusterId, warehouseId, err))
-		}
-
-		stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
-			clusterAPI: clusterAPI,
-			actionID:   resp.ActionID,
-			clusterID:  clusterId,
-			timeout:    common.DeployOrScaleClusterTimeout,
-			pendingStates: []string{
-				string(cluster.ClusterStateRunning),
-				string(cluster.ClusterStateScaling)},
-			targetStates: []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
-		})
+		step := fmt.Sprintf("warehouse[%s].compute_node_size", warehouseName)
+		err = checkpoint.runAction(step, moduleReached(ctx, clusterAPI, clusterId, warehouseId, func(m *cluster.Module, _ *cluster.Warehouse) bool {
+			return m.InstanceType == vmCate
+		}), func() (string, error) {
+			resp, err := clusterAPI.ScaleWarehouseSize(ctx, scaleReq)
+			if err != nil {
+				return "", fmt.Errorf("failed to scale warehouse size, clusterId:%s warehouseId:%s, errMsg:%s", clusterId, warehouseId, err)
+			}
+			return resp.ActionID, nil
+		}, waitWarehouseScaling(ctx, clusterAPI, clusterId))
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("waiting for cluster (%s) running: %s", clusterId, err))
-		}
-
-		if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
-			return diag.FromErr(errors.New(stateResp.AbnormalReason))
+			return diag.FromErr(err)
 		}
 	}
 
@@ -2614,31 +2739,21 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 	computeNodeCountChanged := oldParamMap["compute_node_count"].(int) != newParamMap["compute_node_count"].(int)
 	if computeNodeCountChanged {
 		vmNum := int32(newParamMap["compute_node_count"].(int))
-		resp, err := clusterAPI.ScaleWarehouseNum(ctx, &cluster.ScaleWarehouseNumReq{
-			WarehouseId: warehouseId,
-			VmNum:       vmNum,
-		})
-
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to scale warehouse number, clusterId:%s warehouseId:%s, errMsg:%s", clusterId, warehouseId, err))
-		}
-
-		stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
-			clusterAPI: clusterAPI,
-			actionID:   resp.ActionID,
-			clusterID:  clusterId,
-			timeout:    common.DeployOrScaleClusterTimeout,
-			pendingStates: []string{
-				string(cluster.ClusterStateRunning),
-				string(cluster.ClusterStateScaling)},
-			targetStates: []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
-		})
+		step := fmt.Sprintf("warehouse[%s].compute_node_count", warehouseName)
+		err := checkpoint.runAction(step, moduleReached(ctx, clusterAPI, clusterId, warehouseId, func(m *cluster.Module, _ *cluster.Warehouse) bool {
+			return int32(m.Num) == vmNum
+		}), func() (string, error) {
+			resp, err := clusterAPI.ScaleWarehouseNum(ctx, &cluster.ScaleWarehouseNumReq{
+				WarehouseId: warehouseId,
+				VmNum:       vmNum,
+			})
+			if err != nil {
+				return "", fmt.Errorf("failed to scale warehouse number, clusterId:%s warehouseId:%s, errMsg:%s", clusterId, warehouseId, err)
+			}
+			return resp.ActionID, nil
+		}, waitWarehouseScaling(ctx, clusterAPI, clusterId))
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("waiting for cluster (%s) running: %s", clusterId, err))
-		}
-
-		if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
-			return diag.FromErr(errors.New(stateResp.AbnormalReason))
+			return diag.FromErr(err)
 		}
 	}
 
@@ -2661,22 +2776,17 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 		if computeNodeSizeChanged {
 			beVmCate = newParamMap["compute_node_size"].(string)
 		}
-		beVmInfoResp, err := clusterAPI.GetVmInfo(ctx, &cluster.GetVmInfoReq{
-			Csp:         csp,
-			Region:      region,
-			ProcessType: string(cluster.ClusterModuleTypeBE),
-			VmCate:      beVmCate,
-		})
+		beVmInfo, err := req.vmCatalog.Get(ctx, csp, region, string(cluster.ClusterModuleTypeBE), beVmCate)
 		if err != nil {
 			log.Printf("[ERROR] query instance type failed, csp:%s region:%s instance type:%s err:%+v", csp, region, beVmCate, err)
 			return diag.FromErr(fmt.Errorf("query instance type failed, csp:%s region:%s instance type:%s errMsg:%s", csp, region, beVmCate, err.Error()))
 		}
-		if beVmInfoResp.VmInfo == nil {
+		if beVmInfo == nil {
 			return diag.FromErr(fmt.Errorf("instance type not exists, csp:%s region:%s instance type:%s", csp, region, beVmCate))
 		}
 
-		if newVolumeConfig["vol_number"].(int) > int(beVmInfoResp.VmInfo.MaxDataDiskCount) {
-			return diag.FromErr(fmt.Errorf("the maximum allowed `vol_number` for this VM type is: %d", beVmInfoResp.VmInfo.MaxDataDiskCount))
+		if newVolumeConfig["vol_number"].(int) > int(beVmInfo.MaxDataDiskCount) {
+			return diag.FromErr(fmt.Errorf("the maximum allowed `vol_number` for this VM type is: %d", beVmInfo.MaxDataDiskCount))
 		}
 
 		if newVolumeConfig["vol_number"].(int) < 1 {
@@ -2701,19 +2811,27 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 			req.Throughput = int64(v.(int))
 		}
 
-		log.Printf("[DEBUG] modify warehouse[%s] volume config, req:%+v", warehouseName, req)
-		modifyVolumeResp, err := clusterAPI.ModifyClusterVolume(ctx, req)
-		if err != nil {
-			log.Printf("[ERROR] modify warehouse[%s] volume config failed, err:%+v", warehouseName, err)
-			return diag.FromErr(err)
-		}
-		infraActionId := modifyVolumeResp.ActionID
-		if len(infraActionId) > 0 {
+		step := fmt.Sprintf("warehouse[%s].compute_node_volume_config", warehouseName)
+		err = checkpoint.runAction(step, moduleReached(ctx, clusterAPI, clusterId, warehouseId, func(m *cluster.Module, _ *cluster.Warehouse) bool {
+			return volumeReached(m, req)
+		}), func() (string, error) {
+			log.Printf("[DEBUG] modify warehouse[%s] volume config, req:%+v", warehouseName, req)
+			modifyVolumeResp, err := clusterAPI.ModifyClusterVolume(ctx, req)
+			if err != nil {
+				log.Printf("[ERROR] modify warehouse[%s] volume config failed, err:%+v", warehouseName, err)
+				return "", err
+			}
+			return modifyVolumeResp.ActionID, nil
+		}, func(infraActionId string) error {
+			if len(infraActionId) == 0 {
+				return nil
+			}
+
 			infraActionResp, err := WaitClusterInfraActionStateChangeComplete(ctx, &waitStateReq{
 				clusterAPI: clusterAPI,
 				clusterID:  clusterId,
 				actionID:   infraActionId,
-				timeout:    30 * time.Minute,
+				timeout:    timeoutFromContext(ctx),
 				pendingStates: []string{
 					string(cluster.ClusterInfraActionStatePending),
 					string(cluster.ClusterInfraActionStateOngoing),
@@ -2724,27 +2842,22 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 					string(cluster.ClusterInfraActionStateFailed),
 				},
 			})
-
-			summary := fmt.Sprintf("Modify warehouse[%s] volume config failed", warehouseName)
-
 			if err != nil {
-				return diag.Diagnostics{
-					diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  summary,
-						Detail:   err.Error(),
-					},
-				}
+				return err
 			}
 
 			if infraActionResp.InfraActionState == string(cluster.ClusterInfraActionStateFailed) {
-				return diag.Diagnostics{
-					diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  summary,
-						Detail:   infraActionResp.ErrMsg,
-					},
-				}
+				return errors.New(infraActionResp.ErrMsg)
+			}
+			return nil
+		})
+		if err != nil {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Modify warehouse[%s] volume config failed", warehouseName),
+					Detail:   err.Error(),
+				},
 			}
 		}
 	}
@@ -2771,6 +2884,20 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 		}
 	}
 
+	// Modify scheduling policies
+	if !isDefaultWarehouse && warehouseSchedulingPoliciesChanged(oldParamMap["scheduling_policy"], newParamMap["scheduling_policy"]) {
+		err := updateWarehouseSchedulingPolicies(ctx, clusterAPI, warehouseId, newParamMap["scheduling_policy"].([]interface{}))
+		if err != nil {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Config warehouse[%s] scheduling policies failed", warehouseName),
+					Detail:   err.Error(),
+				},
+			}
+		}
+	}
+
 	// Modify sr config
 	oldSrConfigMap := oldParamMap["compute_node_configs"].(map[string]interface{})
 	oldConfigs := make(map[string]string, 0)
@@ -2876,6 +3003,30 @@ func updateWarehouse(ctx context.Context, req *UpdateWarehouseReq, multiAz bool)
 	return nil
 }
 
+// waitWarehouseScaling waits for the scaling action of a warehouse.
+func waitWarehouseScaling(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string) func(actionId string) error {
+	return func(actionId string) error {
+		stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
+			clusterAPI: clusterAPI,
+			actionID:   actionId,
+			clusterID:  clusterId,
+			timeout:    timeoutFromContext(ctx),
+			pendingStates: []string{
+				string(cluster.ClusterStateRunning),
+				string(cluster.ClusterStateScaling)},
+			targetStates: []string{string(cluster.ClusterStateRunning), string(cluster.ClusterStateAbnormal)},
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for cluster (%s) running: %s", clusterId, err)
+		}
+
+		if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
+			return errors.New(stateResp.AbnormalReason)
+		}
+		return nil
+	}
+}
+
 func DeleteWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId, warehouseId string) (diags diag.Diagnostics) {
 
 	resp, err := clusterAPI.ReleaseWarehouse(ctx, &cluster.ReleaseWarehouseReq{
@@ -2893,7 +3044,7 @@ func DeleteWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 			clusterAPI: clusterAPI,
 			clusterID:  clusterId,
 			actionID:   resp.ActionID,
-			timeout:    common.DeployOrScaleClusterTimeout,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterStateDeploying),
 				string(cluster.ClusterStateRunning),
@@ -2940,7 +3091,7 @@ func SuspendWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, clust
 			clusterAPI: clusterAPI,
 			clusterID:  clusterId,
 			actionID:   infraActionId,
-			timeout:    common.DeployOrScaleClusterTimeout,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterStateDeploying),
 				string(cluster.ClusterStateRunning),
@@ -2999,7 +3150,7 @@ func ResumeWarehouse(ctx context.Context, clusterAPI cluster.IClusterAPI, cluste
 			clusterAPI: clusterAPI,
 			clusterID:  clusterId,
 			actionID:   infraActionId,
-			timeout:    common.DeployOrScaleClusterTimeout,
+			timeout:    timeoutFromContext(ctx),
 			pendingStates: []string{
 				string(cluster.ClusterStateDeploying),
 				string(cluster.ClusterStateScaling),
@@ -3042,12 +3193,15 @@ func IsAllRunning(c *cluster.Cluster) bool {
 }
 
 type UpdateWarehouseReq struct {
-	d              *schema.ResourceData
+	csp            string
+	region         string
 	clusterAPI     cluster.IClusterAPI
+	vmCatalog      *cluster.VmCatalog
 	clusterId      string
 	oldParamMap    map[string]interface{}
 	newParamMap    map[string]interface{}
 	whExternalInfo *cluster.WarehouseExternalInfo
+	checkpoint     *updateCheckpoint
 }
 
 func configArrowFlight(ctx context.Context, clusterAPI cluster.IClusterAPI, req *cluster.SetClusterArrowFlightReq) diag.Diagnostics {
@@ -3070,7 +3224,7 @@ func configArrowFlight(ctx context.Context, clusterAPI cluster.IClusterAPI, req
 		clusterAPI: clusterAPI,
 		clusterID:  req.ClusterId,
 		actionID:   resp.ActionID,
-		timeout:    30 * time.Minute,
+		timeout:    timeoutFromContext(ctx),
 		pendingStates: []string{
 			string(cluster.ClusterInfraActionStatePending),
 			string(cluster.ClusterInfraActionStateOngoing),
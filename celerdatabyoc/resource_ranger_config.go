@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
 	rangerconfig "terraform-provider-celerdatabyoc/celerdata-sdk/service/ranger-config"
+	"terraform-provider-celerdatabyoc/common"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -70,6 +71,12 @@ func resourceRangerConfig() *schema.Resource {
 				ValidateFunc: validation.StringMatch(regexp.MustCompile(`\.xml$`), "must end with .xml"),
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Update: schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
+		},
 	}
 }
 
@@ -0,0 +1,709 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-celerdatabyoc/celerdata-sdk/client"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/network"
+	"terraform-provider-celerdatabyoc/common"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func resourceWarehouse() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWarehouseCreate,
+		ReadContext:   resourceWarehouseRead,
+		UpdateContext: resourceWarehouseUpdate,
+		DeleteContext: resourceWarehouseDelete,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateWarehouseName,
+			},
+			"compute_node_size": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"compute_node_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"distribution_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					SPECIFY_AZ,
+					CROSSING_AZ,
+				}, false),
+			},
+			"specify_az": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource_tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of tags to assign to the resource. For AWS, these are tags; for GCP, these are labels.",
+			},
+			"compute_node_volume_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vol_number": {
+							Description:  "Specifies the number of disk. The default value is 2.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      2,
+							ValidateFunc: validation.IntBetween(1, 16),
+						},
+						"vol_size": {
+							Description:      "Specifies the size of a single disk in GB. The default size for per disk is 100GB.",
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          100,
+							ValidateDiagFunc: common.ValidateVolumeSize(),
+						},
+						"iops": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Computed:     true,
+						},
+						"throughput": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Computed:     true,
+						},
+					},
+				},
+			},
+			"idle_suspend_interval": {
+				Type:        schema.TypeInt,
+				Description: "Specifies the amount of time (in minutes) during which a warehouse can stay idle. After the specified time period elapses, the warehouse will be automatically suspended.",
+				Optional:    true,
+				Default:     0,
+				ValidateFunc: validation.Any(
+					validation.IntInSlice([]int{0}),
+					validation.IntBetween(15, 999999),
+				),
+			},
+			"auto_scaling_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentAutoScalingPolicy,
+				ValidateFunc: func(i interface{}, s string) ([]string, []error) {
+					err := ValidateAutoScalingPolicyStr(i.(string))
+					if err != nil {
+						return nil, []error{err}
+					}
+					return nil, nil
+				},
+			},
+			"scheduling_policy": warehouseSchedulingPolicySchema(),
+			"expected_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(cluster.ClusterStateRunning),
+				ValidateFunc: validation.StringInSlice([]string{string(cluster.ClusterStateSuspended), string(cluster.ClusterStateRunning)}, false),
+			},
+			"compute_node_configs": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceWarehouseImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Read:   schema.DefaultTimeout(common.DefaultWaitTimeout),
+			Update: schema.DefaultTimeout(common.DeployOrScaleClusterTimeout),
+			Delete: schema.DefaultTimeout(common.DefaultWaitTimeout),
+		},
+		CustomizeDiff: customizeWarehouseDiff,
+	}
+}
+
+// warehouseParamKeys are the attributes of celerdatabyoc_warehouse that are shared with the `warehouse` block of celerdatabyoc_elastic_cluster_v2.
+var warehouseParamKeys = []string{
+	"name",
+	"compute_node_size",
+	"compute_node_count",
+	"distribution_policy",
+	"specify_az",
+	"resource_tags",
+	"compute_node_volume_config",
+	"idle_suspend_interval",
+	"auto_scaling_policy",
+	"expected_state",
+	"compute_node_configs",
+	"scheduling_policy",
+}
+
+var clusterLocks sync.Map
+
+// lockCluster serializes the operations on the warehouses of a cluster, the cluster runs one infra action at a time.
+// The lock only covers the resources of one provider process: the resources of a cluster that are managed by
+// different configurations or different terraform runs are not serialized by it, they rely on waitClusterStable.
+func lockCluster(clusterId string) func() {
+	v, _ := clusterLocks.LoadOrStore(clusterId, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+func customizeWarehouseDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("cluster_id") {
+		return nil
+	}
+
+	c := m.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+	networkAPI := network.NewNetworkAPI(c)
+	vmCatalog := cluster.SharedVmCatalog(c)
+
+	clusterId := d.Get("cluster_id").(string)
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		return fmt.Errorf("failed to get cluster (%s): %s", clusterId, err.Error())
+	}
+
+	csp, region := resp.Cluster.Csp, resp.Cluster.Region
+	coordinatorVmInfo, err := getVmInfo(ctx, vmCatalog, csp, region, string(cluster.ClusterModuleTypeFE), resp.Cluster.FeModule.InstanceType, cty.GetAttrPath("cluster_id"))
+	if err != nil {
+		return err
+	}
+
+	netResp, err := networkAPI.GetNetwork(ctx, resp.Cluster.NetIfaceID)
+	if err != nil {
+		return fmt.Errorf("get network (%s): %s", resp.Cluster.NetIfaceID, err.Error())
+	}
+
+	whName := d.Get("name").(string)
+	distributionPolicy := d.Get("distribution_policy").(string)
+	if netResp.Network.MultiAz && len(distributionPolicy) == 0 {
+		return cty.GetAttrPath("distribution_policy").NewErrorf("in multi-AZ deployment mode, the distribution_policy parameter of warehouse[%s] can not be empty", whName)
+	}
+	if !netResp.Network.MultiAz && len(distributionPolicy) > 0 {
+		return cty.GetAttrPath("distribution_policy").NewErrorf("in single-AZ deployment mode, the distribution_policy parameter of warehouse[%s] must be empty", whName)
+	}
+	if distributionPolicy != SPECIFY_AZ && len(d.Get("specify_az").(string)) > 0 {
+		return cty.GetAttrPath("specify_az").NewErrorf("specify_az parameter of warehouse[%s] only takes effect when the distribution_policy value is \"specify_az\"", whName)
+	}
+
+	if len(d.Id()) == 0 || d.HasChange("compute_node_size") || d.HasChange("compute_node_volume_config") {
+		whParamMap := make(map[string]interface{}, len(warehouseParamKeys))
+		for _, k := range warehouseParamKeys {
+			whParamMap[k] = d.Get(k)
+		}
+		err = verifyWarehouseVmInfo(ctx, vmCatalog, csp, region, coordinatorVmInfo.Arch, cty.Path{}, whParamMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := checkWarehouseSchedulingPolicies(cty.Path{}, d.Get("scheduling_policy").([]interface{})); err != nil {
+		return err
+	}
+
+	if v, ok := d.GetOk("resource_tags"); ok {
+		for k := range v.(map[string]interface{}) {
+			if IsInternalTagKeys(csp, k) {
+				return cty.GetAttrPath("resource_tags").IndexString(k).NewErrorf("warehouse:%s tag key %s is reserved for internal use and cannot be set", whName, k)
+			}
+			if _, ok := resp.Cluster.Tags[k]; ok {
+				return cty.GetAttrPath("resource_tags").IndexString(k).NewErrorf("tag key %s is duplicated between cluster tags and warehouse tags", k)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceWarehouseImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return nil, fmt.Errorf("invalid import id %q, expected <cluster_id>/<warehouse_name>", d.Id())
+	}
+	clusterId, whName := parts[0], parts[1]
+
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster (%s): %s", clusterId, err.Error())
+	}
+
+	for _, wh := range resp.Cluster.Warehouses {
+		if wh.Deleted || wh.Name != whName {
+			continue
+		}
+		if wh.IsDefaultWarehouse {
+			return nil, fmt.Errorf("the default warehouse of cluster (%s) is managed by the cluster resource", clusterId)
+		}
+		d.SetId(wh.Id)
+		d.Set("cluster_id", clusterId)
+		d.Set("name", whName)
+		return []*schema.ResourceData{d}, nil
+	}
+
+	return nil, fmt.Errorf("warehouse[%s] not found in cluster (%s)", whName, clusterId)
+}
+
+func resourceWarehouseCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutCreate)); diags != nil {
+		return diags
+	}
+
+	whParamMap := make(map[string]interface{}, len(warehouseParamKeys))
+	for _, k := range warehouseParamKeys {
+		whParamMap[k] = d.Get(k)
+	}
+
+	warehouseId, diags := createWarehouse(ctx, clusterAPI, clusterId, whParamMap)
+	if len(warehouseId) == 0 || diags.HasError() {
+		if len(warehouseId) > 0 {
+			d.SetId(warehouseId)
+		}
+		return diags
+	}
+	d.SetId(warehouseId)
+
+	return append(diags, resourceWarehouseRead(ctx, d, m)...)
+}
+
+func resourceWarehouseRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+	networkAPI := network.NewNetworkAPI(c)
+	clusterId := d.Get("cluster_id").(string)
+	warehouseId := d.Id()
+
+	log.Printf("[DEBUG] get cluster, cluster[%s]", clusterId)
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		if !d.IsNewResource() && status.Code(err) == codes.NotFound {
+			log.Printf("[WARN] Cluster (%s) not found, removing warehouse (%s) from state", clusterId, warehouseId)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	var warehouse *cluster.Warehouse
+	for _, wh := range resp.Cluster.Warehouses {
+		if wh.Id == warehouseId && !wh.Deleted {
+			warehouse = wh
+			break
+		}
+	}
+	if resp.Cluster.ClusterState == cluster.ClusterStateReleased || warehouse == nil {
+		log.Printf("[WARN] Warehouse (%s) not found, removing from state", warehouseId)
+		d.SetId("")
+		return nil
+	}
+
+	netResp, err := networkAPI.GetNetwork(ctx, resp.Cluster.NetIfaceID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get network (%s): %s", resp.Cluster.NetIfaceID, err.Error()))
+	}
+
+	whMap, diags := flattenWarehouse(ctx, clusterAPI, clusterId, resp.Cluster.Csp, netResp.Network.MultiAz, warehouse, true)
+	if diags != nil {
+		return diags
+	}
+
+	// flattenWarehouse omits the attributes the warehouse doesn't have, they are reset here so the changes
+	// made outside of terraform show up in the plan.
+	for k, v := range map[string]interface{}{
+		"compute_node_volume_config": []interface{}{},
+		"compute_node_configs":       map[string]interface{}{},
+		"auto_scaling_policy":        "",
+		"idle_suspend_interval":      0,
+		"scheduling_policy":          []interface{}{},
+	} {
+		if _, ok := whMap[k]; !ok {
+			whMap[k] = v
+		}
+	}
+
+	for _, k := range warehouseParamKeys {
+		if err := d.Set(k, whMap[k]); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set %s of warehouse (%s): %s", k, warehouseId, err.Error()))
+		}
+	}
+	d.Set("cluster_id", clusterId)
+	d.Set("state", string(warehouse.State))
+
+	return nil
+}
+
+func resourceWarehouseUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.CelerdataClient)
+	clusterAPI := cluster.NewClustersAPI(c)
+	networkAPI := network.NewNetworkAPI(c)
+	clusterId := d.Get("cluster_id").(string)
+	warehouseId := d.Id()
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	if diags := waitClusterStable(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutUpdate)); diags != nil {
+		return diags
+	}
+
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	netResp, err := networkAPI.GetNetwork(ctx, resp.Cluster.NetIfaceID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get network (%s): %s", resp.Cluster.NetIfaceID, err.Error()))
+	}
+
+	whResp, err := clusterAPI.GetWarehouse(ctx, &cluster.GetWarehouseReq{WarehouseId: warehouseId})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to get warehouse (%s): %s", warehouseId, err.Error()))
+	}
+
+	oldParamMap := make(map[string]interface{}, len(warehouseParamKeys))
+	newParamMap := make(map[string]interface{}, len(warehouseParamKeys))
+	for _, k := range warehouseParamKeys {
+		oldParamMap[k], newParamMap[k] = d.GetChange(k)
+	}
+
+	diags := updateWarehouse(ctx, &UpdateWarehouseReq{
+		csp:         resp.Cluster.Csp,
+		region:      resp.Cluster.Region,
+		clusterAPI:  clusterAPI,
+		vmCatalog:   cluster.SharedVmCatalog(c),
+		clusterId:   clusterId,
+		oldParamMap: oldParamMap,
+		newParamMap: newParamMap,
+		whExternalInfo: &cluster.WarehouseExternalInfo{
+			Id:                 warehouseId,
+			IsInstanceStore:    whResp.Info.IsInstanceStore,
+			IsDefaultWarehouse: whResp.Info.IsDefault,
+		},
+	}, netResp.Network.MultiAz)
+	if diags != nil {
+		return diags
+	}
+
+	return resourceWarehouseRead(ctx, d, m)
+}
+
+func resourceWarehouseDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clusterAPI := cluster.NewClustersAPI(m.(*client.CelerdataClient))
+	clusterId := d.Get("cluster_id").(string)
+
+	unlock := lockCluster(clusterId)
+	defer unlock()
+
+	state, diags := waitClusterState(ctx, clusterAPI, clusterId, d.Timeout(schema.TimeoutDelete))
+	if diags != nil {
+		return diags
+	}
+
+	if state == string(cluster.ClusterStateReleased) {
+		log.Printf("[WARN] Cluster (%s) is released, so is warehouse (%s)", clusterId, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	diags = DeleteWarehouse(ctx, clusterAPI, clusterId, d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// waitClusterStable waits for the ongoing operation of the cluster to finish and fails if the cluster is released or abnormal.
+func waitClusterStable(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, timeout time.Duration) diag.Diagnostics {
+	state, diags := waitClusterState(ctx, clusterAPI, clusterId, timeout)
+	if diags != nil {
+		return diags
+	}
+
+	if state == string(cluster.ClusterStateReleased) {
+		return diag.FromErr(fmt.Errorf("cluster (%s) not found", clusterId))
+	}
+	return nil
+}
+
+// waitClusterState waits for the ongoing operation of the cluster to finish and returns the state of the cluster.
+// It fails if the cluster is abnormal.
+func waitClusterState(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId string, timeout time.Duration) (string, diag.Diagnostics) {
+	stateResp, err := WaitClusterStateChangeComplete(ctx, &waitStateReq{
+		clusterAPI: clusterAPI,
+		clusterID:  clusterId,
+		timeout:    timeout,
+		pendingStates: []string{
+			string(cluster.ClusterStateDeploying),
+			string(cluster.ClusterStateScaling),
+			string(cluster.ClusterStateResuming),
+			string(cluster.ClusterStateSuspending),
+			string(cluster.ClusterStateReleasing),
+			string(cluster.ClusterStateUpdating),
+		},
+		targetStates: []string{
+			string(cluster.ClusterStateRunning),
+			string(cluster.ClusterStateSuspended),
+			string(cluster.ClusterStateAbnormal),
+			string(cluster.ClusterStateReleased),
+		},
+	})
+	if err != nil {
+		return "", diag.FromErr(fmt.Errorf("waiting for cluster (%s) change complete: %s", clusterId, err))
+	}
+
+	if stateResp.ClusterState == string(cluster.ClusterStateAbnormal) {
+		return stateResp.ClusterState, diag.FromErr(errors.New(stateResp.AbnormalReason))
+	}
+	return stateResp.ClusterState, nil
+}
+
+// warehouseSchedulingPolicySchema is the `scheduling_policy` block of a warehouse, like the one of the cluster resources.
+func warehouseSchedulingPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 5,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"policy_name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"description": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"time_zone": {
+					Type:         schema.TypeString,
+					Description:  "IANA Time-Zone",
+					Optional:     true,
+					Default:      "UTC",
+					ValidateFunc: common.ValidateSchedulingPolicyTimeZone,
+				},
+				"active_days": {
+					Type:     schema.TypeSet,
+					Required: true,
+					MinItems: 1,
+					MaxItems: 7,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(cluster.WeekDays, false),
+					},
+				},
+				"resume_at": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: common.ValidateSchedulingPolicyDateTime,
+				},
+				"suspend_at": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: common.ValidateSchedulingPolicyDateTime,
+				},
+				"enable": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+			},
+		},
+	}
+}
+
+// checkWarehouseSchedulingPolicies checks the `scheduling_policy` blocks of the warehouse at path.
+func checkWarehouseSchedulingPolicies(path cty.Path, policies []interface{}) error {
+	policyNames := make(map[string]bool)
+	for i, item := range policies {
+		m := item.(map[string]interface{})
+		policyName := m["policy_name"].(string)
+		if policyNames[policyName] {
+			return path.GetAttr("scheduling_policy").IndexInt(i).GetAttr("policy_name").NewErrorf("duplicate scheduling policy name `%s`", policyName)
+		}
+		if err := checkSchedulingPolicyTime(policyName, m["resume_at"].(string), m["suspend_at"].(string)); err != nil {
+			return path.GetAttr("scheduling_policy").IndexInt(i).NewError(err)
+		}
+		policyNames[policyName] = true
+	}
+	return nil
+}
+
+// errWarehouseSchedulingPolicyUnavailable is returned when the CelerData deployment doesn't serve the scheduling policy
+// endpoints of warehouses.
+var errWarehouseSchedulingPolicyUnavailable = errors.New("the scheduling policies of warehouses are not supported by this CelerData deployment")
+
+// listWarehouseSchedulingPolicies returns the scheduling policies of the warehouse and their ids by name.
+func listWarehouseSchedulingPolicies(ctx context.Context, clusterAPI cluster.IClusterAPI, warehouseId string) ([]map[string]interface{}, map[string]string, error) {
+	resp, err := clusterAPI.ListWarehouseSchedulePolicy(ctx, &cluster.ListWarehouseSchedulePolicyReq{
+		WarehouseId: warehouseId,
+	})
+	if err != nil {
+		log.Printf("[ERROR] list warehouse scheduling policy failed, warehouse[%s] err:%+v", warehouseId, err)
+		if client.IsUnsupported(err) || status.Code(err) == codes.NotFound {
+			return nil, nil, fmt.Errorf("%w: %s", errWarehouseSchedulingPolicyUnavailable, err.Error())
+		}
+		return nil, nil, err
+	}
+
+	policies := make([]map[string]interface{}, 0)
+	policyIds := make(map[string]string)
+	for _, item := range resp.SchedulePolicies {
+		activeDays := make([]string, 0)
+		for _, day := range strings.Split(item.ActiveDateValue, ",") {
+			if len(strings.TrimSpace(day)) > 0 {
+				activeDays = append(activeDays, strings.TrimSpace(day))
+			}
+		}
+		policies = append(policies, map[string]interface{}{
+			"policy_name": item.PolicyName,
+			"description": item.Description,
+			"time_zone":   item.TimeZone,
+			"active_days": activeDays,
+			"resume_at":   item.ResumeAt,
+			"suspend_at":  item.SuspendAt,
+			"enable":      item.State == int32(1),
+		})
+		policyIds[item.PolicyName] = item.PolicyId
+	}
+	return policies, policyIds, nil
+}
+
+// warehouseSchedulingPoliciesChanged compares the `scheduling_policy` blocks of a warehouse, either of which may be nil.
+func warehouseSchedulingPoliciesChanged(o, n interface{}) bool {
+	flatten := func(v interface{}) []string {
+		ret := make([]string, 0)
+		policies, _ := v.([]interface{})
+		for _, item := range policies {
+			m := item.(map[string]interface{})
+			days := make([]string, 0)
+			for _, day := range m["active_days"].(*schema.Set).List() {
+				days = append(days, day.(string))
+			}
+			sort.Strings(days)
+			ret = append(ret, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%t", m["policy_name"], m["description"], m["time_zone"],
+				strings.Join(days, ","), m["resume_at"], m["suspend_at"], m["enable"]))
+		}
+		sort.Strings(ret)
+		return ret
+	}
+	return strings.Join(flatten(o), "\n") != strings.Join(flatten(n), "\n")
+}
+
+// updateWarehouseSchedulingPolicies makes the scheduling policies of the warehouse match the `scheduling_policy` blocks.
+// Policies are matched by name, so renaming a policy replaces it.
+func updateWarehouseSchedulingPolicies(ctx context.Context, clusterAPI cluster.IClusterAPI, warehouseId string, policies []interface{}) error {
+	_, policyIds, err := listWarehouseSchedulingPolicies(ctx, clusterAPI, warehouseId)
+	if errors.Is(err, errWarehouseSchedulingPolicyUnavailable) && len(policies) == 0 {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool)
+	for _, item := range policies {
+		declared[item.(map[string]interface{})["policy_name"].(string)] = true
+	}
+	for policyName, policyId := range policyIds {
+		if declared[policyName] {
+			continue
+		}
+		err := clusterAPI.DeleteWarehouseSchedulePolicy(ctx, &cluster.DeleteWarehouseSchedulePolicyReq{
+			WarehouseId: warehouseId,
+			PolicyId:    policyId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete scheduling policy[%s]: %s", policyName, err.Error())
+		}
+	}
+
+	for _, item := range policies {
+		m := item.(map[string]interface{})
+		policyName := m["policy_name"].(string)
+		dayArr := make([]string, 0)
+		for _, day := range m["active_days"].(*schema.Set).List() {
+			dayArr = append(dayArr, day.(string))
+		}
+		sort.Strings(dayArr)
+
+		if policyId, ok := policyIds[policyName]; ok {
+			err = clusterAPI.ModifyWarehouseSchedulePolicy(ctx, &cluster.ModifyWarehouseSchedulePolicyReq{
+				PolicyId:    policyId,
+				WarehouseId: warehouseId,
+				PolicyName:  policyName,
+				Description: m["description"].(string),
+				TimeZone:    m["time_zone"].(string),
+				ActiveDays:  strings.Join(dayArr, ","),
+				ResumeAt:    m["resume_at"].(string),
+				SuspendAt:   m["suspend_at"].(string),
+				State:       m["enable"].(bool),
+			})
+		} else {
+			_, err = clusterAPI.SaveWarehouseSchedulePolicy(ctx, &cluster.SaveWarehouseSchedulePolicyReq{
+				WarehouseId: warehouseId,
+				PolicyName:  policyName,
+				Description: m["description"].(string),
+				TimeZone:    m["time_zone"].(string),
+				ActiveDays:  strings.Join(dayArr, ","),
+				ResumeAt:    m["resume_at"].(string),
+				SuspendAt:   m["suspend_at"].(string),
+				State:       m["enable"].(bool),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save scheduling policy[%s]: %s", policyName, err.Error())
+		}
+		log.Printf("[DEBUG] save warehouse scheduling policy, warehouse[%s] paramMap:%+v", warehouseId, m)
+	}
+	return nil
+}
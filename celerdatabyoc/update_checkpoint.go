@@ -0,0 +1,196 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The plugin SDK stores the planned values of every attribute when an update fails halfway, so the
+// steps that were never performed would disappear from the next plan. updateCheckpoint puts the
+// previous values of the steps that didn't complete back into the state, so the next apply resumes
+// from the failed step.
+//
+// The plugin SDK doesn't expose the private state of a resource to CRUD functions, so the infra actions
+// issued by an update are not recorded. Instead, an update first waits for the actions that are still in
+// flight to finish, and the cluster itself tells whether the action of a step already brought it to the
+// target of the step, in which case the action is not issued again.
+
+type updateCheckpoint struct {
+	d          *schema.ResourceData
+	keys       []string
+	completed  map[string]bool
+	warehouses map[string]bool
+	finished   bool
+}
+
+// newUpdateCheckpoint tracks the update of keys, which are performed in the given order.
+func newUpdateCheckpoint(d *schema.ResourceData, keys []string) *updateCheckpoint {
+	return &updateCheckpoint{
+		d:          d,
+		keys:       keys,
+		completed:  make(map[string]bool),
+		warehouses: make(map[string]bool),
+	}
+}
+
+// complete marks the update of key as completed.
+func (c *updateCheckpoint) complete(key string) {
+	if c == nil {
+		return
+	}
+	c.completed[key] = true
+}
+
+// completeWarehouse marks the creation, update or removal of the warehouse whName as completed.
+func (c *updateCheckpoint) completeWarehouse(whName string) {
+	if c == nil {
+		return
+	}
+	c.warehouses[whName] = true
+}
+
+// finish marks the whole update as completed.
+func (c *updateCheckpoint) finish() {
+	if c == nil {
+		return
+	}
+	c.finished = true
+}
+
+// rollback puts the previous values of the keys whose update didn't complete back into the state.
+// It's a no-op if the update finished. Otherwise an error is added to diags, so an update that stopped
+// on warnings is not reported as successful.
+func (c *updateCheckpoint) rollback(diags *diag.Diagnostics) {
+	if c == nil || c.finished {
+		return
+	}
+
+	incomplete := make([]string, 0)
+	for _, k := range c.keys {
+		if c.completed[k] || !c.d.HasChange(k) {
+			continue
+		}
+		incomplete = append(incomplete, k)
+
+		var err error
+		if k == "warehouse" {
+			err = c.d.Set(k, c.warehouseProgress())
+		} else {
+			o, _ := c.d.GetChange(k)
+			err = c.d.Set(k, o)
+		}
+		if err != nil {
+			log.Printf("[ERROR] failed to roll back %s of cluster (%s): %+v", k, c.d.Id(), err)
+		} else {
+			log.Printf("[DEBUG] the update of %s of cluster (%s) didn't complete, keep its previous value", k, c.d.Id())
+		}
+	}
+
+	if !diags.HasError() {
+		*diags = append(*diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("The update of cluster (%s) didn't complete", c.d.Id()),
+			Detail:   fmt.Sprintf("The update of %v didn't complete, they keep their previous values. Apply again to resume the update.", incomplete),
+		})
+	}
+}
+
+// warehouseProgress returns the warehouses of the state: the planned ones that completed and the previous ones that didn't.
+func (c *updateCheckpoint) warehouseProgress() []interface{} {
+	o, n := c.d.GetChange("warehouse")
+
+	oldWhMap := make(map[string]interface{})
+	for _, v := range o.([]interface{}) {
+		oldWhMap[v.(map[string]interface{})["name"].(string)] = v
+	}
+	newWhMap := make(map[string]interface{})
+	for _, v := range n.([]interface{}) {
+		newWhMap[v.(map[string]interface{})["name"].(string)] = v
+	}
+
+	ret := make([]interface{}, 0)
+	for _, v := range n.([]interface{}) {
+		whName := v.(map[string]interface{})["name"].(string)
+		if c.warehouses[whName] {
+			ret = append(ret, v)
+		} else if oldWh, ok := oldWhMap[whName]; ok {
+			ret = append(ret, oldWh)
+		}
+	}
+	for _, v := range o.([]interface{}) {
+		whName := v.(map[string]interface{})["name"].(string)
+		if _, ok := newWhMap[whName]; !ok && !c.warehouses[whName] {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// runAction runs the infra action of step. reached reports whether the cluster is already at the target of
+// step, for example because the action issued by a previous apply completed after the apply failed, in which
+// case the action is not issued again. start issues the action and returns its id, wait waits for it.
+func (c *updateCheckpoint) runAction(step string, reached func() (bool, error), start func() (string, error), wait func(actionId string) error) error {
+	ok, err := reached()
+	if err != nil {
+		return err
+	}
+	if ok {
+		log.Printf("[DEBUG] skip step %s of cluster update, the cluster is already at its target", step)
+		return nil
+	}
+
+	actionId, err := start()
+	if err != nil {
+		return err
+	}
+	return wait(actionId)
+}
+
+// clusterModule returns the coordinator module of the cluster, or the module of the warehouse if warehouseId
+// isn't empty. It returns nil if the cluster doesn't report it.
+func clusterModule(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId, warehouseId string) (*cluster.Module, *cluster.Warehouse, error) {
+	resp, err := clusterAPI.Get(ctx, &cluster.GetReq{ClusterID: clusterId})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cluster (%s): %s", clusterId, err.Error())
+	}
+	if resp.Cluster == nil {
+		return nil, nil, fmt.Errorf("cluster (%s) not found", clusterId)
+	}
+	if len(warehouseId) == 0 {
+		return resp.Cluster.FeModule, nil, nil
+	}
+	for _, wh := range resp.Cluster.Warehouses {
+		if wh.Id == warehouseId {
+			return wh.Module, wh, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// moduleReached returns a reached function of updateCheckpoint.runAction that checks the coordinator module,
+// or the module of the warehouse if warehouseId isn't empty, with check.
+func moduleReached(ctx context.Context, clusterAPI cluster.IClusterAPI, clusterId, warehouseId string, check func(m *cluster.Module, wh *cluster.Warehouse) bool) func() (bool, error) {
+	return func() (bool, error) {
+		m, wh, err := clusterModule(ctx, clusterAPI, clusterId, warehouseId)
+		if err != nil {
+			return false, err
+		}
+		if m == nil {
+			return false, nil
+		}
+		return check(m, wh), nil
+	}
+}
+
+// volumeReached reports whether the volumes of m already match the fields that req modifies.
+func volumeReached(m *cluster.Module, req *cluster.ModifyClusterVolumeReq) bool {
+	return (req.VmVolSize == 0 || m.VmVolSizeGB == req.VmVolSize) &&
+		(req.VmVolNum == 0 || m.VmVolNum == req.VmVolNum) &&
+		(req.Iops == 0 || m.Iops == req.Iops) &&
+		(req.Throughput == 0 || m.Throughput == req.Throughput)
+}
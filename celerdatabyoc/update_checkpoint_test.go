@@ -0,0 +1,281 @@
+package celerdatabyoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"terraform-provider-celerdatabyoc/celerdata-sdk/service/cluster"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+var testCheckpointSchema = map[string]*schema.Schema{
+	"size": {
+		Type:     schema.TypeString,
+		Optional: true,
+	},
+	"count": {
+		Type:     schema.TypeInt,
+		Optional: true,
+	},
+	"warehouse": {
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"count": {
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+			},
+		},
+	},
+}
+
+// testCheckpointData returns the resource data of an update from the state attributes to the config.
+func testCheckpointData(t *testing.T, attributes map[string]string, config map[string]interface{}) *schema.ResourceData {
+	sm := schema.InternalMap(testCheckpointSchema)
+	state := &terraform.InstanceState{ID: "c1", Attributes: attributes}
+	diff, err := sm.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil, nil, true)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	d, err := sm.Data(state, diff)
+	if err != nil {
+		t.Fatalf("data failed: %v", err)
+	}
+	return d
+}
+
+func TestUpdateCheckpointRollback(t *testing.T) {
+	attributes := map[string]string{
+		"id":                "c1",
+		"size":              "small",
+		"count":             "1",
+		"warehouse.#":       "2",
+		"warehouse.0.name":  "wh1",
+		"warehouse.0.count": "1",
+		"warehouse.1.name":  "wh2",
+		"warehouse.1.count": "1",
+	}
+	config := map[string]interface{}{
+		"size":  "large",
+		"count": 3,
+		"warehouse": []interface{}{
+			map[string]interface{}{"name": "wh1", "count": 2},
+			map[string]interface{}{"name": "wh3", "count": 1},
+		},
+	}
+
+	cases := []struct {
+		name          string
+		completed     []string
+		warehouses    []string
+		finished      bool
+		diags         diag.Diagnostics
+		wantSize      string
+		wantCount     int
+		wantWarehouse []string
+		wantError     bool
+	}{
+		{
+			name:          "finished",
+			completed:     []string{"size"},
+			finished:      true,
+			wantSize:      "large",
+			wantCount:     3,
+			wantWarehouse: []string{"wh1:2", "wh3:1"},
+		},
+		{
+			name:          "stopped on error",
+			completed:     []string{"size"},
+			diags:         diag.Errorf("scale out failed"),
+			wantSize:      "large",
+			wantCount:     1,
+			wantWarehouse: []string{"wh1:1", "wh2:1"},
+			wantError:     true,
+		},
+		{
+			name:          "stopped on warnings",
+			diags:         diag.Diagnostics{{Severity: diag.Warning, Summary: "failed to apply configs"}},
+			wantSize:      "small",
+			wantCount:     1,
+			wantWarehouse: []string{"wh1:1", "wh2:1"},
+			wantError:     true,
+		},
+		{
+			name:          "some warehouses completed",
+			completed:     []string{"size", "count"},
+			warehouses:    []string{"wh1", "wh2"},
+			wantSize:      "large",
+			wantCount:     3,
+			wantWarehouse: []string{"wh1:2"},
+			wantError:     true,
+		},
+		{
+			name:          "new warehouse completed",
+			completed:     []string{"size", "count"},
+			warehouses:    []string{"wh3"},
+			wantSize:      "large",
+			wantCount:     3,
+			wantWarehouse: []string{"wh1:1", "wh3:1", "wh2:1"},
+			wantError:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := testCheckpointData(t, attributes, config)
+			c := newUpdateCheckpoint(d, []string{"size", "count", "warehouse"})
+			for _, k := range tc.completed {
+				c.complete(k)
+			}
+			for _, wh := range tc.warehouses {
+				c.completeWarehouse(wh)
+			}
+			if tc.finished {
+				c.finish()
+			}
+
+			diags := tc.diags
+			c.rollback(&diags)
+
+			if got := d.Get("size").(string); got != tc.wantSize {
+				t.Errorf("size = %s, want %s", got, tc.wantSize)
+			}
+			if got := d.Get("count").(int); got != tc.wantCount {
+				t.Errorf("count = %d, want %d", got, tc.wantCount)
+			}
+			gotWarehouse := make([]string, 0)
+			for _, v := range d.Get("warehouse").([]interface{}) {
+				wh := v.(map[string]interface{})
+				gotWarehouse = append(gotWarehouse, fmt.Sprintf("%s:%d", wh["name"], wh["count"]))
+			}
+			if !reflect.DeepEqual(gotWarehouse, tc.wantWarehouse) {
+				t.Errorf("warehouse = %v, want %v", gotWarehouse, tc.wantWarehouse)
+			}
+			if diags.HasError() != tc.wantError {
+				t.Errorf("diags has error = %v, want %v: %+v", diags.HasError(), tc.wantError, diags)
+			}
+			if tc.diags.HasError() && len(diags) != len(tc.diags) {
+				t.Errorf("rollback added diagnostics to an update that failed already: %+v", diags)
+			}
+		})
+	}
+}
+
+func TestUpdateCheckpointRunAction(t *testing.T) {
+	errGet := errors.New("get cluster failed")
+	errStart := errors.New("scale up failed")
+	errWait := errors.New("cluster abnormal")
+
+	cases := []struct {
+		name       string
+		reached    bool
+		reachedErr error
+		startErr   error
+		waitErr    error
+		wantStart  bool
+		wantWait   bool
+		wantErr    error
+	}{
+		{
+			name:      "issued and waited",
+			wantStart: true,
+			wantWait:  true,
+		},
+		{
+			name:    "already reached",
+			reached: true,
+		},
+		{
+			name:       "reached unknown",
+			reachedErr: errGet,
+			wantErr:    errGet,
+		},
+		{
+			name:      "issue failed",
+			startErr:  errStart,
+			wantStart: true,
+			wantErr:   errStart,
+		},
+		{
+			name:      "action failed",
+			waitErr:   errWait,
+			wantStart: true,
+			wantWait:  true,
+			wantErr:   errWait,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			started, waited := false, ""
+			c := &updateCheckpoint{}
+			err := c.runAction("coordinator_node_size", func() (bool, error) {
+				return tc.reached, tc.reachedErr
+			}, func() (string, error) {
+				started = true
+				return "action-1", tc.startErr
+			}, func(actionId string) error {
+				waited = actionId
+				return tc.waitErr
+			})
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("runAction error = %v, want %v", err, tc.wantErr)
+			}
+			if started != tc.wantStart {
+				t.Errorf("action started = %v, want %v", started, tc.wantStart)
+			}
+			if (waited == "action-1") != tc.wantWait {
+				t.Errorf("action waited = %q, want waited %v", waited, tc.wantWait)
+			}
+		})
+	}
+}
+
+func TestVolumeReached(t *testing.T) {
+	m := &cluster.Module{VmVolSizeGB: 100, VmVolNum: 2, Iops: 3000, Throughput: 125}
+
+	cases := []struct {
+		name string
+		req  *cluster.ModifyClusterVolumeReq
+		want bool
+	}{
+		{
+			name: "nothing modified",
+			req:  &cluster.ModifyClusterVolumeReq{},
+			want: true,
+		},
+		{
+			name: "size reached",
+			req:  &cluster.ModifyClusterVolumeReq{VmVolSize: 100, Iops: 3000},
+			want: true,
+		},
+		{
+			name: "size not reached",
+			req:  &cluster.ModifyClusterVolumeReq{VmVolSize: 200},
+		},
+		{
+			name: "throughput not reached",
+			req:  &cluster.ModifyClusterVolumeReq{VmVolNum: 2, Throughput: 250},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := volumeReached(m, tc.req); got != tc.want {
+				t.Errorf("volumeReached() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
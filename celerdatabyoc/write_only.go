@@ -0,0 +1,87 @@
+package celerdatabyoc
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Write-only attributes (`<name>_wo`) carry secrets that Terraform never stores in the plan or the state, they
+// require Terraform 1.11 or later. Their value is only available from the configuration during create and update,
+// so a change of the companion `<name>_wo_version` attribute is what triggers the secret to be sent again.
+
+const (
+	writeOnlySuffix        = "_wo"
+	writeOnlyVersionSuffix = "_wo_version"
+)
+
+func writeOnlyKey(key string) string {
+	return key + writeOnlySuffix
+}
+
+func writeOnlyVersionKey(key string) string {
+	return key + writeOnlyVersionSuffix
+}
+
+// writeOnlySchema returns the schema of `<key>_wo`, the write-only counterpart of the sensitive attribute `key`.
+func writeOnlySchema(key string, description string, validate schema.SchemaValidateDiagFunc) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		WriteOnly:        true,
+		Sensitive:        true,
+		Description:      description + " The value is write-only and is never stored in the state.",
+		ValidateDiagFunc: validate,
+		ExactlyOneOf:     []string{key, writeOnlyKey(key)},
+	}
+}
+
+// writeOnlyVersionSchema returns the schema of `<key>_wo_version`, which triggers `<key>_wo` to be applied again.
+func writeOnlyVersionSchema(key string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Description:  "Change this value to send the value of `" + writeOnlyKey(key) + "` to CelerData again.",
+		RequiredWith: []string{writeOnlyKey(key)},
+	}
+}
+
+// preferWriteOnly warns the users of Terraform versions that support write-only attributes to use `<key>_wo`
+// instead of each of keys.
+func preferWriteOnly(keys ...string) []schema.ValidateRawResourceConfigFunc {
+	ret := make([]schema.ValidateRawResourceConfigFunc, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, validation.PreferWriteOnlyAttribute(cty.GetAttrPath(k), cty.GetAttrPath(writeOnlyKey(k))))
+	}
+	return ret
+}
+
+// getWriteOnly returns the value of a write-only attribute from the configuration. The configuration is only
+// available during create and update, so an empty string is returned during read, import and delete.
+func getWriteOnly(d *schema.ResourceData, key string) string {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() || v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+	return v.AsString()
+}
+
+// getSecret returns the value of `<key>_wo` if it's configured, otherwise the value of `key`.
+func getSecret(d *schema.ResourceData, key string) string {
+	if v := getWriteOnly(d, writeOnlyKey(key)); len(v) > 0 {
+		return v
+	}
+	return d.Get(key).(string)
+}
+
+// secretHasChange reports whether either the sensitive attribute or its write-only version changed.
+func secretHasChange(d *schema.ResourceData, key string) bool {
+	return d.HasChange(key) || d.HasChange(writeOnlyVersionKey(key))
+}
+
+// movedToWriteOnly reports whether the sensitive attribute `key` is removed from the configuration because the
+// user switched to `<key>_wo`. Clearing the old value from the state is allowed even if `key` can't be modified.
+func movedToWriteOnly(d *schema.ResourceData, key string) bool {
+	o, n := d.GetChange(key)
+	return len(o.(string)) > 0 && len(n.(string)) == 0 && len(getWriteOnly(d, writeOnlyKey(key))) > 0
+}
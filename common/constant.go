@@ -4,4 +4,5 @@ import "time"
 
 const (
 	DeployOrScaleClusterTimeout = 6 * time.Hour
+	DefaultWaitTimeout          = 30 * time.Minute
 )